@@ -0,0 +1,300 @@
+package clients
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+// ActivityPublisher publishes activity messages off the request path. Messages are
+// buffered in memory and published in batches; when the broker is unavailable they are
+// spilled to a local file and replayed once publishing succeeds again. Delivery is at
+// least once: a batch that failed half way is spilled and published again in full.
+type ActivityPublisher struct {
+	rabbitMQ   *RabbitMQ
+	routingKey string
+	cfg        *config.ActivityPublisherConfig
+
+	messages chan *models.ActivityMessage
+	stop     chan struct{}
+	finished chan struct{}
+
+	// mu orders buffering against Stop: once Stop holds it no message can enter the
+	// buffer, so everything buffered is seen by drain and later messages are spilled
+	mu      sync.RWMutex
+	stopped bool
+	// spillMu guards the spill file; the replay file is only touched by the flush goroutine
+	spillMu sync.Mutex
+}
+
+func NewActivityPublisher(rabbitMQ *RabbitMQ, cfg *config.MessagingConfig) *ActivityPublisher {
+	return &ActivityPublisher{
+		rabbitMQ:   rabbitMQ,
+		routingKey: cfg.Queues.UserActivity.RoutingKey,
+		cfg:        &cfg.ActivityPublisher,
+		messages:   make(chan *models.ActivityMessage, cfg.ActivityPublisher.BufferSize),
+		stop:       make(chan struct{}),
+		finished:   make(chan struct{}),
+	}
+}
+
+// Publish queues the message without blocking. If the buffer is full or the
+// publisher is stopped, the message is spilled to disk instead.
+func (p *ActivityPublisher) Publish(message *models.ActivityMessage) {
+	if p.buffer(message) {
+		return
+	}
+	p.spill([]*models.ActivityMessage{message})
+}
+
+// buffer adds the message to the buffer unless the publisher is stopped or the buffer is full
+func (p *ActivityPublisher) buffer(message *models.ActivityMessage) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.stopped {
+		return false
+	}
+	select {
+	case p.messages <- message:
+		return true
+	default:
+		logrus.Warn("Activity publisher buffer is full, spilling message to disk")
+		return false
+	}
+}
+
+// Start flushes buffered messages in the background until Stop is called
+func (p *ActivityPublisher) Start() {
+	interval := time.Duration(p.cfg.FlushIntervalMs) * time.Millisecond
+	logrus.WithFields(logrus.Fields{
+		"batch_size": p.cfg.BatchSize, "flush_interval": interval,
+	}).Info("Starting activity publisher")
+
+	go func() {
+		defer close(p.finished)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		batch := make([]*models.ActivityMessage, 0, p.cfg.BatchSize)
+		for {
+			select {
+			case message := <-p.messages:
+				batch = append(batch, message)
+				if len(batch) >= p.cfg.BatchSize {
+					batch = p.flush(batch)
+				}
+			case <-ticker.C:
+				batch = p.flush(batch)
+				p.replaySpilled()
+			case <-p.stop:
+				p.drain(batch)
+				return
+			}
+		}
+	}()
+}
+
+// Stop publishes everything still buffered, spilling what cannot be published
+func (p *ActivityPublisher) Stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+
+	close(p.stop)
+	<-p.finished
+	logrus.Info("Activity publisher stopped")
+}
+
+// drain flushes the pending batch and whatever is left in the buffer
+func (p *ActivityPublisher) drain(batch []*models.ActivityMessage) {
+	for {
+		select {
+		case message := <-p.messages:
+			batch = append(batch, message)
+			if len(batch) >= p.cfg.BatchSize {
+				batch = p.flush(batch)
+			}
+		default:
+			p.flush(batch)
+			return
+		}
+	}
+}
+
+// flush publishes the batch, spilling it on failure, and returns the emptied batch
+func (p *ActivityPublisher) flush(batch []*models.ActivityMessage) []*models.ActivityMessage {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	if err := p.publishBatch(batch); err != nil {
+		logrus.WithError(err).WithField("messages", len(batch)).Warn("Failed to publish activity batch, spilling to disk")
+		p.spill(batch)
+	}
+	return batch[:0]
+}
+
+func (p *ActivityPublisher) publishBatch(batch []*models.ActivityMessage) error {
+	publishings := make([]amqp.Publishing, 0, len(batch))
+	for _, message := range batch {
+		body, err := json.Marshal(message)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to marshal activity message, dropping it")
+			continue
+		}
+		publishings = append(publishings, amqp.Publishing{
+			ContentType: "application/json",
+			Body:        body,
+			Timestamp:   message.Timestamp,
+		})
+	}
+
+	return p.rabbitMQ.PublishBatch(p.routingKey, publishings)
+}
+
+// spill appends the messages to the spill file as JSON lines
+func (p *ActivityPublisher) spill(messages []*models.ActivityMessage) {
+	p.spillMu.Lock()
+	defer p.spillMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(p.cfg.SpillFile), 0o700); err != nil {
+		logrus.WithError(err).WithField("messages", len(messages)).Error("Failed to spill activity messages, dropping them")
+		return
+	}
+
+	file, err := os.OpenFile(p.cfg.SpillFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		logrus.WithError(err).WithField("messages", len(messages)).Error("Failed to spill activity messages, dropping them")
+		return
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, message := range messages {
+		if err := encoder.Encode(message); err != nil {
+			logrus.WithError(err).Error("Failed to spill activity message, dropping it")
+		}
+	}
+}
+
+// replaySpilled publishes spilled messages batch by batch. The spill file is moved aside
+// first, so request goroutines keep spilling to a fresh file while the broker confirms
+// the replay. If a batch fails, the replay file is rewritten to hold only the messages
+// not yet published and is replayed again on a later tick.
+func (p *ActivityPublisher) replaySpilled() {
+	path := p.cfg.SpillFile + ".replay"
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if !p.takeSpillFile(path) {
+			return
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to open activity replay file")
+		return
+	}
+	defer file.Close()
+
+	batch := make([]*models.ActivityMessage, 0, p.cfg.BatchSize)
+	replayed := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message models.ActivityMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			logrus.WithError(err).Warn("Skipping malformed spilled activity message")
+			continue
+		}
+
+		batch = append(batch, &message)
+		if len(batch) < p.cfg.BatchSize {
+			continue
+		}
+		if err := p.publishBatch(batch); err != nil {
+			logrus.WithError(err).Debug("Broker still unavailable, keeping spilled activity")
+			p.keepUnpublished(path, batch, scanner)
+			return
+		}
+		replayed += len(batch)
+		batch = batch[:0]
+	}
+	if err := scanner.Err(); err != nil {
+		logrus.WithError(err).Error("Failed to read activity replay file")
+		return
+	}
+
+	if err := p.publishBatch(batch); err != nil {
+		logrus.WithError(err).Debug("Broker still unavailable, keeping spilled activity")
+		p.keepUnpublished(path, batch, scanner)
+		return
+	}
+	replayed += len(batch)
+
+	if err := os.Remove(path); err != nil {
+		logrus.WithError(err).Error("Failed to remove activity replay file")
+		return
+	}
+	logrus.WithField("messages", replayed).Info("Replayed spilled activity messages")
+}
+
+// takeSpillFile moves the spill file to path, reporting whether there was one to replay
+func (p *ActivityPublisher) takeSpillFile(path string) bool {
+	p.spillMu.Lock()
+	defer p.spillMu.Unlock()
+
+	if err := os.Rename(p.cfg.SpillFile, path); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logrus.WithError(err).Error("Failed to move activity spill file for replay")
+		}
+		return false
+	}
+	return true
+}
+
+// keepUnpublished replaces the replay file with the failed batch followed by the lines
+// the scanner has not read yet, so published batches are not sent again
+func (p *ActivityPublisher) keepUnpublished(path string, batch []*models.ActivityMessage, scanner *bufio.Scanner) {
+	remainder, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create activity replay remainder, replaying the whole file again")
+		return
+	}
+	defer os.Remove(remainder.Name())
+	defer remainder.Close()
+
+	writer := bufio.NewWriter(remainder)
+	encoder := json.NewEncoder(writer)
+	for _, message := range batch {
+		if err := encoder.Encode(message); err != nil {
+			logrus.WithError(err).Error("Failed to write activity replay remainder, replaying the whole file again")
+			return
+		}
+	}
+	for scanner.Scan() {
+		writer.Write(scanner.Bytes())
+		writer.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		logrus.WithError(err).Error("Failed to read activity replay file, replaying the whole file again")
+		return
+	}
+	if err := writer.Flush(); err != nil {
+		logrus.WithError(err).Error("Failed to write activity replay remainder, replaying the whole file again")
+		return
+	}
+
+	if err := os.Rename(remainder.Name(), path); err != nil {
+		logrus.WithError(err).Error("Failed to replace activity replay file, replaying the whole file again")
+	}
+}
@@ -0,0 +1,96 @@
+package clients
+
+import (
+	"bufio"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestActivityPublisher returns a publisher whose broker is never connected, so every
+// message it does not lose ends up in the spill file
+func newTestActivityPublisher(t *testing.T) *ActivityPublisher {
+	cfg := &config.MessagingConfig{}
+	cfg.ActivityPublisher = config.ActivityPublisherConfig{
+		BufferSize: 64,
+		BatchSize:  8,
+		// Long enough that no replay moves the spill file during the test
+		FlushIntervalMs: int(time.Hour / time.Millisecond),
+		SpillFile:       filepath.Join(t.TempDir(), "activity.spill"),
+	}
+	return NewActivityPublisher(&RabbitMQ{cfg: cfg}, cfg)
+}
+
+func countSpilled(t *testing.T, path string) int {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func TestActivityPublisherStop(t *testing.T) {
+	tests := []struct {
+		name       string
+		before     int
+		during     int
+		publishers int
+		after      int
+	}{
+		{name: "buffered messages are drained", before: 20},
+		{name: "more messages than the buffer holds", before: 200},
+		{name: "messages published while stopping are kept", during: 100, publishers: 8},
+		{name: "messages published after stop are spilled", before: 5, after: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestActivityPublisher(t)
+			p.Start()
+
+			message := &models.ActivityMessage{UserID: "user", Timestamp: time.Now()}
+			for i := 0; i < tt.before; i++ {
+				p.Publish(message)
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < tt.publishers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < tt.during; j++ {
+						p.Publish(message)
+					}
+				}()
+			}
+			p.Stop()
+			wg.Wait()
+
+			for i := 0; i < tt.after; i++ {
+				p.Publish(message)
+			}
+
+			want := tt.before + tt.during*tt.publishers + tt.after
+			if got := countSpilled(t, p.cfg.SpillFile); got != want {
+				t.Errorf("spilled messages = %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -17,16 +17,16 @@ import (
 type AuthClient struct {
 	baseURL    string
 	httpClient *http.Client
-	channel    *amqp.Channel
+	rabbitMQ   *RabbitMQ
 	cfg        *config.MessagingConfig
 }
 
 // NewAuthClient creates new auth service client
-func NewAuthClient(cfg *config.Configuration, channel *amqp.Channel) *AuthClient {
+func NewAuthClient(cfg *config.Configuration, rabbitMQ *RabbitMQ) *AuthClient {
 	return &AuthClient{
-		baseURL: cfg.ExternalServices.AuthService.URL,
-		channel: channel,
-		cfg:     &cfg.Messaging,
+		baseURL:  cfg.ExternalServices.AuthService.URL,
+		rabbitMQ: rabbitMQ,
+		cfg:      &cfg.Messaging,
 		httpClient: &http.Client{
 			Timeout: time.Duration(cfg.ExternalServices.AuthService.Timeout) * time.Second,
 		},
@@ -91,11 +91,8 @@ func (c *AuthClient) PublishActivityWithDetails(userID, sessionID, serviceName,
 		return fmt.Errorf("failed to marshal activity message: %w", err)
 	}
 
-	err = c.channel.Publish(
-		c.cfg.RabbitMQ.Exchange,
+	err = c.rabbitMQ.Publish(
 		c.cfg.Queues.UserActivity.RoutingKey,
-		false, // mandatory
-		false, // immediate
 		amqp.Publishing{
 			ContentType: "application/json",
 			Body:        body,
@@ -119,3 +116,33 @@ func (c *AuthClient) PublishActivityWithDetails(userID, sessionID, serviceName,
 
 	return nil
 }
+
+// PublishEvent publishes a JSON encoded event to the exchange with the given routing key
+func (c *AuthClient) PublishEvent(routingKey string, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	err = c.rabbitMQ.Publish(
+		routingKey,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+			Timestamp:    time.Now(),
+		},
+	)
+
+	if err != nil {
+		logrus.WithError(err).WithField("routing_key", routingKey).Error("Failed to publish event")
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"exchange":    c.cfg.RabbitMQ.Exchange,
+		"routing_key": routingKey,
+	}).Debug("Event published")
+
+	return nil
+}
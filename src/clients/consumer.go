@@ -0,0 +1,279 @@
+package clients
+
+import (
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+// Headers set on messages that failed processing
+const (
+	HeaderRetryCount         = "x-retry-count"
+	HeaderFailureReason      = "x-failure-reason"
+	HeaderFailedAt           = "x-failed-at"
+	HeaderOriginalQueue      = "x-original-queue"
+	HeaderOriginalRoutingKey = "x-original-routing-key"
+)
+
+var (
+	// ErrUnprocessableMessage marks failures retrying cannot fix, such as malformed payloads;
+	// the message goes to the dead-letter queue right away
+	ErrUnprocessableMessage = errors.New("message cannot be processed")
+
+	errConsumerStopped = errors.New("consumer stopped")
+)
+
+// DeliveryHandler processes a single message, returning nil acknowledges it
+type DeliveryHandler func(delivery amqp.Delivery) error
+
+// QueueConsumer consumes a queue and resubscribes when the broker connection is lost.
+// Messages failing processing are republished to the queue with an increased retry count
+// and, once the queue's max retries are used up, sent to its dead-letter queue with
+// the failure reason in the headers.
+type QueueConsumer struct {
+	rabbitMQ *RabbitMQ
+	queue    config.QueueConfig
+	source   config.QueueConfig
+	autoAck  bool
+	handler  DeliveryHandler
+
+	// deadLetters is set when consuming the dead-letter queue itself, failures are
+	// requeued there instead of being dead-lettered again
+	deadLetters bool
+
+	mu       sync.Mutex
+	channel  *amqp.Channel
+	stop     chan struct{}
+	finished chan struct{}
+}
+
+func NewQueueConsumer(rabbitMQ *RabbitMQ, queue config.QueueConfig, autoAck bool, handler DeliveryHandler) *QueueConsumer {
+	return &QueueConsumer{
+		rabbitMQ: rabbitMQ,
+		queue:    queue,
+		source:   queue,
+		autoAck:  autoAck,
+		handler:  handler,
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+// NewDeadLetterConsumer consumes the dead-letter queue of the queue, always with manual acks
+func NewDeadLetterConsumer(rabbitMQ *RabbitMQ, queue config.QueueConfig, handler DeliveryHandler) *QueueConsumer {
+	return &QueueConsumer{
+		rabbitMQ: rabbitMQ,
+		queue:    queue,
+		source: config.QueueConfig{
+			Name:     queue.DeadLetterQueue,
+			Consumer: queue.DeadLetterConsumer,
+		},
+		handler:     handler,
+		deadLetters: true,
+		stop:        make(chan struct{}),
+		finished:    make(chan struct{}),
+	}
+}
+
+// Start consumes the queue in the background until Stop is called
+func (c *QueueConsumer) Start() {
+	logrus.WithField("queue", c.source.Name).Info("Queue consumer started")
+
+	go func() {
+		defer close(c.finished)
+		for {
+			deliveries, err := c.subscribe()
+			if errors.Is(err, errConsumerStopped) {
+				return
+			}
+			if err != nil {
+				logrus.WithError(err).WithField("queue", c.source.Name).Error("Failed to start queue consumer")
+			} else {
+				for delivery := range deliveries {
+					c.handle(delivery)
+				}
+			}
+
+			select {
+			case <-c.stop:
+				return
+			case <-time.After(c.rabbitMQ.ReconnectDelay()):
+				logrus.WithField("queue", c.source.Name).Info("Resubscribing queue consumer")
+			}
+		}
+	}()
+}
+
+// Stop cancels the consumer and waits for in-flight messages to be handled
+func (c *QueueConsumer) Stop() {
+	close(c.stop)
+
+	c.mu.Lock()
+	channel := c.channel
+	c.mu.Unlock()
+
+	if channel != nil {
+		if err := channel.Cancel(c.source.Consumer, false); err != nil {
+			logrus.WithError(err).WithField("queue", c.source.Name).Error("Failed to cancel queue consumer")
+		}
+	}
+
+	<-c.finished
+
+	if channel != nil {
+		if err := channel.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+			logrus.WithError(err).WithField("queue", c.source.Name).Error("Failed to close queue consumer channel")
+		}
+	}
+	logrus.WithField("queue", c.source.Name).Info("Queue consumer stopped")
+}
+
+// subscribe declares the queues and opens a consumer channel; queues are declared
+// again on every subscription as they may be gone after a broker restart
+func (c *QueueConsumer) subscribe() (<-chan amqp.Delivery, error) {
+	if err := c.rabbitMQ.SetupConsumerQueue(c.queue); err != nil {
+		return nil, err
+	}
+
+	channel, deliveries, err := c.rabbitMQ.Consume(c.source, c.autoAck)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.stop:
+		// Stopped while subscribing, Stop will not see this channel
+		channel.Close()
+		return nil, errConsumerStopped
+	default:
+	}
+	c.channel = channel
+
+	return deliveries, nil
+}
+
+func (c *QueueConsumer) handle(delivery amqp.Delivery) {
+	err := c.handler(delivery)
+	switch {
+	case err == nil:
+		c.ack(delivery)
+	case c.autoAck:
+		logrus.WithError(err).WithField("queue", c.source.Name).Error("Failed to process auto-acked message, message is lost")
+	case c.deadLetters:
+		c.requeue(delivery, err)
+	default:
+		c.retryOrDeadLetter(delivery, err)
+	}
+}
+
+func (c *QueueConsumer) retryOrDeadLetter(delivery amqp.Delivery, reason error) {
+	retries := RetryCount(delivery.Headers)
+	logger := logrus.WithError(reason).WithFields(logrus.Fields{
+		"queue": c.queue.Name, "message_id": delivery.MessageId, "retries": retries,
+	})
+
+	message := republish(delivery)
+	message.Headers[HeaderFailureReason] = reason.Error()
+	if _, ok := message.Headers[HeaderOriginalRoutingKey]; !ok {
+		// Retries go through the default exchange, keep the routing key the message was published with
+		message.Headers[HeaderOriginalRoutingKey] = delivery.RoutingKey
+	}
+
+	if !errors.Is(reason, ErrUnprocessableMessage) && retries < c.queue.MaxRetries {
+		message.Headers[HeaderRetryCount] = int32(retries + 1)
+		if err := c.rabbitMQ.PublishToQueue(c.queue.Name, message); err != nil {
+			logger.WithField("publish_error", err.Error()).Error("Failed to republish message for retry, requeueing")
+			c.nack(delivery, true)
+			return
+		}
+		logger.Warn("Message processing failed, retrying")
+		c.ack(delivery)
+		return
+	}
+
+	message.Headers[HeaderRetryCount] = int32(retries)
+	message.Headers[HeaderOriginalQueue] = c.queue.Name
+	message.Headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339)
+	if err := c.rabbitMQ.PublishDeadLetter(c.queue, message); err != nil {
+		// Rejecting lets the broker dead-letter the message, without the failure headers
+		logger.WithField("publish_error", err.Error()).Error("Failed to publish message to dead-letter queue, rejecting")
+		c.nack(delivery, false)
+		return
+	}
+	logger.WithField("dead_letter_queue", c.queue.DeadLetterQueue).Error("Message processing failed, dead-lettered")
+	c.ack(delivery)
+}
+
+// requeue puts a dead letter that could not be handled back after the reconnect delay,
+// so a storage outage does not turn into a redelivery loop
+func (c *QueueConsumer) requeue(delivery amqp.Delivery, reason error) {
+	logrus.WithError(reason).WithFields(logrus.Fields{
+		"queue": c.source.Name, "message_id": delivery.MessageId,
+	}).Error("Failed to handle dead-lettered message, requeueing")
+
+	select {
+	case <-c.stop:
+	case <-time.After(c.rabbitMQ.ReconnectDelay()):
+	}
+	c.nack(delivery, true)
+}
+
+func (c *QueueConsumer) ack(delivery amqp.Delivery) {
+	if c.autoAck {
+		return
+	}
+	if err := delivery.Ack(false); err != nil {
+		logrus.WithError(err).WithField("queue", c.source.Name).Error("Failed to ack message")
+	}
+}
+
+func (c *QueueConsumer) nack(delivery amqp.Delivery, requeue bool) {
+	if err := delivery.Nack(false, requeue); err != nil {
+		logrus.WithError(err).WithField("queue", c.source.Name).Error("Failed to nack message")
+	}
+}
+
+// RetryCount reads the number of retries already made from the message headers
+func RetryCount(headers amqp.Table) int {
+	switch value := headers[HeaderRetryCount].(type) {
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	case string:
+		count, _ := strconv.Atoi(value)
+		return count
+	default:
+		return 0
+	}
+}
+
+// republish copies the delivered message into a new persistent publishing
+func republish(delivery amqp.Delivery) amqp.Publishing {
+	headers := make(amqp.Table, len(delivery.Headers)+5)
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     delivery.ContentType,
+		ContentEncoding: delivery.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		CorrelationId:   delivery.CorrelationId,
+		MessageId:       delivery.MessageId,
+		Timestamp:       delivery.Timestamp,
+		Type:            delivery.Type,
+		AppId:           delivery.AppId,
+		Body:            delivery.Body,
+	}
+}
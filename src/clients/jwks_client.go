@@ -0,0 +1,204 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minJWKSRefreshInterval limits refetches triggered by unknown key IDs
+const minJWKSRefreshInterval = 30 * time.Second
+
+var ErrSigningKeyNotFound = errors.New("signing key not found in JWKS")
+
+// JWKSClient fetches and caches public signing keys published by the auth service
+type JWKSClient struct {
+	url        string
+	cacheTTL   time.Duration
+	httpClient *http.Client
+
+	refreshMu sync.Mutex
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// NewJWKSClient creates new JWKS client
+func NewJWKSClient(url string, cacheTTL, timeout time.Duration) *JWKSClient {
+	return &JWKSClient{
+		url:        url,
+		cacheTTL:   cacheTTL,
+		httpClient: &http.Client{Timeout: timeout},
+		keys:       make(map[string]interface{}),
+	}
+}
+
+// GetKey returns public key by key ID, refreshing the key set when it is stale or the key is unknown
+func (c *JWKSClient) GetKey(ctx context.Context, kid string) (interface{}, error) {
+	c.mu.RLock()
+	key, found := c.keys[kid]
+	fresh := time.Since(c.fetchedAt) < c.cacheTTL
+	c.mu.RUnlock()
+
+	if found && fresh {
+		return key, nil
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		if found {
+			log.WithError(err).WithField("kid", kid).Warn("Failed to refresh JWKS, using cached key")
+			return key, nil
+		}
+		return nil, err
+	}
+
+	c.mu.RLock()
+	key, found = c.keys[kid]
+	c.mu.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrSigningKeyNotFound, kid)
+	}
+	return key, nil
+}
+
+// refresh fetches the key set unless it was fetched very recently
+func (c *JWKSClient) refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.mu.RLock()
+	recentlyFetched := time.Since(c.fetchedAt) < minJWKSRefreshInterval
+	c.mu.RUnlock()
+
+	if recentlyFetched {
+		return nil
+	}
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+
+	log.WithField("keys", len(keys)).Info("JWKS refreshed")
+	return nil
+}
+
+func (c *JWKSClient) fetch(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint returned status: %d", resp.StatusCode)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kid == "" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			log.WithError(err).WithField("kid", k.Kid).Warn("Skipping invalid JWKS key")
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, err := ellipticCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+func ellipticCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", crv)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
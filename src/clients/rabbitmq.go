@@ -1,67 +1,339 @@
 package clients
 
 import (
+	"errors"
 	"fmt"
 	"handyhub-admin-svc/src/internal/config"
+	"sync"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
 )
 
+const (
+	defaultReconnectDelay    = time.Second
+	defaultMaxReconnectDelay = time.Minute
+	confirmsBufferSize       = 256
+)
+
+var (
+	ErrRabbitMQNotConnected = errors.New("rabbitmq connection is not available")
+	ErrPublishNotConfirmed  = errors.New("message was not confirmed by the broker")
+)
+
+// RabbitMQ manages the broker connection. It watches for connection loss, reconnects
+// with backoff and publishes through a single confirm-mode channel shared by all goroutines.
 type RabbitMQ struct {
-	Conn    *amqp.Connection
-	Channel *amqp.Channel
-	cfg     *config.MessagingConfig
+	cfg *config.MessagingConfig
+
+	mu       sync.RWMutex
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	confirms chan amqp.Confirmation
+	nextTag  uint64
+
+	// publishMu serializes publishes so each publisher waits for its own confirmation
+	publishMu sync.Mutex
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 func NewRabbitMQ(cfg *config.MessagingConfig) (*RabbitMQ, error) {
-	log.WithField("url", "url:"+cfg.RabbitMQ.Url).Info("Connecting to RabbitMQ...")
-	conn, err := amqp.Dial(cfg.RabbitMQ.Url)
-	if err != nil {
-		log.WithError(err).Errorf("Failed to connect to RabbitMQ: %v", err)
-		return nil, err
+	r := &RabbitMQ{
+		cfg:  cfg,
+		done: make(chan struct{}),
 	}
 
-	channel, err := conn.Channel()
-	if err != nil {
-		log.WithError(err).Errorf("Failed to open a channel: %v", err)
+	log.WithField("url", "url:"+cfg.RabbitMQ.Url).Info("Connecting to RabbitMQ...")
+	if err := r.connect(); err != nil {
+		log.WithError(err).Errorf("Failed to connect to RabbitMQ: %v", err)
 		return nil, err
 	}
 
 	log.Infof("Connected to RabbitMQ at %s", cfg.RabbitMQ.Url)
-
-	return &RabbitMQ{
-		Conn:    conn,
-		Channel: channel,
-		cfg:     cfg,
-	}, nil
+	return r, nil
 }
 
+// Close stops reconnecting and closes the publishing channel and the connection
 func (r *RabbitMQ) Close() error {
-	if r.Channel != nil {
-		if err := r.Channel.Close(); err != nil {
+	r.closeOnce.Do(func() { close(r.done) })
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var closeErr error
+	if r.channel != nil {
+		if err := r.channel.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
 			log.WithError(err).Error("Failed to close RabbitMQ channel")
-			return err
+			closeErr = err
 		} else {
 			log.Info("RabbitMQ channel closed")
-			return nil
 		}
+		r.channel = nil
 	}
 
-	if r.Conn != nil {
-		if err := r.Conn.Close(); err != nil {
+	if r.conn != nil {
+		if err := r.conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
 			log.WithError(err).Error("Failed to close RabbitMQ connection")
-			return err
+			if closeErr == nil {
+				closeErr = err
+			}
 		} else {
 			log.Info("RabbitMQ connection closed")
+		}
+		r.conn = nil
+	}
+
+	return closeErr
+}
+
+// Publish sends the message to the exchange and waits for the broker confirmation
+func (r *RabbitMQ) Publish(routingKey string, message amqp.Publishing) error {
+	return r.PublishBatch(routingKey, []amqp.Publishing{message})
+}
+
+// PublishBatch sends the messages to the exchange and waits until the broker confirmed
+// all of them. On error some messages may have been delivered already.
+func (r *RabbitMQ) PublishBatch(routingKey string, messages []amqp.Publishing) error {
+	return r.publish(r.cfg.RabbitMQ.Exchange, routingKey, messages)
+}
+
+// PublishToQueue sends the message straight to the queue through the default exchange,
+// bypassing the bindings of other queues
+func (r *RabbitMQ) PublishToQueue(queueName string, message amqp.Publishing) error {
+	return r.publish("", queueName, []amqp.Publishing{message})
+}
+
+// PublishDeadLetter sends the message to the dead-letter queue of the consumed queue
+func (r *RabbitMQ) PublishDeadLetter(queue config.QueueConfig, message amqp.Publishing) error {
+	return r.publish(r.cfg.RabbitMQ.DeadLetterExchange, queue.DeadLetterQueue, []amqp.Publishing{message})
+}
+
+func (r *RabbitMQ) publish(exchange, routingKey string, messages []amqp.Publishing) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	r.mu.Lock()
+	channel, confirms := r.channel, r.confirms
+	if channel == nil {
+		r.mu.Unlock()
+		return ErrRabbitMQNotConnected
+	}
+	firstTag := r.nextTag + 1
+	for _, message := range messages {
+		if err := channel.Publish(exchange, routingKey, false, false, message); err != nil {
+			r.mu.Unlock()
+			return err
+		}
+		r.nextTag++
+	}
+	lastTag := r.nextTag
+	r.mu.Unlock()
+
+	timeout := time.NewTimer(time.Duration(r.cfg.RabbitMQ.Timeout) * time.Second)
+	defer timeout.Stop()
+
+	nacked := false
+	for {
+		select {
+		case confirm, ok := <-confirms:
+			if !ok {
+				return ErrRabbitMQNotConnected
+			}
+			// Confirmations of earlier publishes that timed out arrive late, skip them
+			if confirm.DeliveryTag < firstTag {
+				continue
+			}
+			if !confirm.Ack {
+				nacked = true
+			}
+			// Confirmations are delivered in delivery tag order
+			if confirm.DeliveryTag < lastTag {
+				continue
+			}
+			if nacked {
+				return ErrPublishNotConfirmed
+			}
 			return nil
+		case <-timeout.C:
+			return fmt.Errorf("%w: timed out after %ds", ErrPublishNotConfirmed, r.cfg.RabbitMQ.Timeout)
 		}
 	}
+}
+
+// SetupConsumerQueue declares the queue, binds it to the exchange and routes
+// rejected messages to its dead-letter queue through the dead-letter exchange
+func (r *RabbitMQ) SetupConsumerQueue(queue config.QueueConfig) error {
+	channel, err := r.openChannel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
 
+	err = channel.ExchangeDeclare(
+		r.cfg.RabbitMQ.DeadLetterExchange,
+		amqp.ExchangeDirect,
+		r.cfg.RabbitMQ.Durable,
+		r.cfg.RabbitMQ.AutoDelete,
+		false, // internal
+		r.cfg.RabbitMQ.NoWait,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %v", err)
+	}
+
+	if _, err := channel.QueueDeclare(
+		queue.DeadLetterQueue,
+		r.cfg.RabbitMQ.Durable,
+		r.cfg.RabbitMQ.AutoDelete,
+		false, // exclusive
+		r.cfg.RabbitMQ.NoWait,
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue %s: %v", queue.DeadLetterQueue, err)
+	}
+
+	if err := channel.QueueBind(
+		queue.DeadLetterQueue,
+		queue.DeadLetterQueue,
+		r.cfg.RabbitMQ.DeadLetterExchange,
+		r.cfg.RabbitMQ.NoWait,
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue %s: %v", queue.DeadLetterQueue, err)
+	}
+
+	if _, err := channel.QueueDeclare(
+		queue.Name,
+		r.cfg.RabbitMQ.Durable,
+		r.cfg.RabbitMQ.AutoDelete,
+		r.cfg.RabbitMQ.Exclusive,
+		r.cfg.RabbitMQ.NoWait,
+		amqp.Table{
+			"x-dead-letter-exchange":    r.cfg.RabbitMQ.DeadLetterExchange,
+			"x-dead-letter-routing-key": queue.DeadLetterQueue,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %v", queue.Name, err)
+	}
+
+	if err := channel.QueueBind(
+		queue.Name,
+		queue.RoutingKey,
+		r.cfg.RabbitMQ.Exchange,
+		r.cfg.RabbitMQ.NoWait,
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to bind queue %s: %v", queue.Name, err)
+	}
+
+	return nil
+}
+
+// Consume opens a dedicated channel with the configured prefetch and starts
+// consuming the queue. The channel is returned so the caller can cancel and close it.
+// The deliveries channel is closed when the connection is lost.
+func (r *RabbitMQ) Consume(queue config.QueueConfig, autoAck bool) (*amqp.Channel, <-chan amqp.Delivery, error) {
+	channel, err := r.openChannel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open consumer channel: %v", err)
+	}
+
+	if err := channel.Qos(r.cfg.RabbitMQ.PrefetchCount, r.cfg.RabbitMQ.PrefetchSize, r.cfg.RabbitMQ.Global); err != nil {
+		channel.Close()
+		return nil, nil, fmt.Errorf("failed to set qos: %v", err)
+	}
+
+	deliveries, err := channel.Consume(
+		queue.Name,
+		queue.Consumer,
+		autoAck,
+		r.cfg.RabbitMQ.Exclusive,
+		r.cfg.RabbitMQ.NoLocal,
+		r.cfg.RabbitMQ.NoWait,
+		nil,
+	)
+	if err != nil {
+		channel.Close()
+		return nil, nil, fmt.Errorf("failed to consume queue %s: %v", queue.Name, err)
+	}
+
+	return channel, deliveries, nil
+}
+
+// ReconnectDelay is the initial wait between connection attempts
+func (r *RabbitMQ) ReconnectDelay() time.Duration {
+	return time.Duration(r.cfg.RabbitMQ.ReconnectDelay) * time.Second
+}
+
+func (r *RabbitMQ) openChannel() (*amqp.Channel, error) {
+	r.mu.RLock()
+	conn := r.conn
+	r.mu.RUnlock()
+
+	if conn == nil {
+		return nil, ErrRabbitMQNotConnected
+	}
+	return conn.Channel()
+}
+
+// connect dials the broker, opens the confirm-mode publishing channel, declares
+// the exchange and starts watching the connection
+func (r *RabbitMQ) connect() error {
+	conn, err := amqp.Dial(r.cfg.RabbitMQ.Url)
+	if err != nil {
+		return err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open a channel: %v", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %v", err)
+	}
+
+	if err := r.declareExchange(channel); err != nil {
+		conn.Close()
+		return err
+	}
+
+	connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	channelClosed := channel.NotifyClose(make(chan *amqp.Error, 1))
+
+	confirms := channel.NotifyPublish(make(chan amqp.Confirmation, confirmsBufferSize))
+
+	r.mu.Lock()
+	select {
+	case <-r.done:
+		// Closed while reconnecting
+		r.mu.Unlock()
+		conn.Close()
+		return nil
+	default:
+	}
+	r.conn = conn
+	r.channel = channel
+	r.confirms = confirms
+	r.nextTag = 0
+	r.mu.Unlock()
+
+	go r.watch(conn, connClosed, channelClosed)
 	return nil
 }
 
-func (r *RabbitMQ) SetupQueue() error {
-	err := r.Channel.ExchangeDeclare(
+func (r *RabbitMQ) declareExchange(channel *amqp.Channel) error {
+	err := channel.ExchangeDeclare(
 		r.cfg.RabbitMQ.Exchange,
 		r.cfg.RabbitMQ.ExchangeType,
 		r.cfg.RabbitMQ.Durable,
@@ -70,10 +342,71 @@ func (r *RabbitMQ) SetupQueue() error {
 		r.cfg.RabbitMQ.NoWait,
 		nil,
 	)
-
 	if err != nil {
 		return fmt.Errorf("failed to declare exchange: %v", err)
 	}
-
 	return nil
 }
+
+// watch waits until the connection or the publishing channel is closed and reconnects,
+// unless the client itself is being closed
+func (r *RabbitMQ) watch(conn *amqp.Connection, connClosed, channelClosed chan *amqp.Error) {
+	var reason *amqp.Error
+	select {
+	case reason = <-connClosed:
+	case reason = <-channelClosed:
+	case <-r.done:
+		return
+	}
+
+	select {
+	case <-r.done:
+		return
+	default:
+	}
+
+	log.WithField("reason", reason).Warn("RabbitMQ connection lost, reconnecting")
+
+	r.mu.Lock()
+	r.channel = nil
+	r.conn = nil
+	r.mu.Unlock()
+	conn.Close()
+
+	r.reconnect()
+}
+
+// reconnect retries with exponential backoff starting at the configured reconnect delay
+func (r *RabbitMQ) reconnect() {
+	delay := r.ReconnectDelay()
+	if delay <= 0 {
+		delay = defaultReconnectDelay
+	}
+	maxDelay := time.Duration(r.cfg.RabbitMQ.MaxReconnectDelay) * time.Second
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxReconnectDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-time.After(delay):
+		case <-r.done:
+			return
+		}
+
+		if err := r.connect(); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"attempt": attempt, "next_delay": delay,
+			}).Warn("RabbitMQ reconnect failed")
+
+			delay *= 2
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+			continue
+		}
+
+		log.WithField("attempt", attempt).Info("Reconnected to RabbitMQ")
+		return
+	}
+}
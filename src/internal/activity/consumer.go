@@ -0,0 +1,57 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// Consumer reads activity messages from the user activity queue and stores them.
+// Messages that keep failing end up in the queue's dead-letter queue.
+type Consumer struct {
+	consumer *clients.QueueConsumer
+	service  Service
+	timeout  time.Duration
+}
+
+func NewConsumer(rabbitMQ *clients.RabbitMQ, service Service, cfg *config.Configuration) *Consumer {
+	c := &Consumer{
+		service: service,
+		timeout: time.Duration(cfg.App.Timeout) * time.Second,
+	}
+	c.consumer = clients.NewQueueConsumer(rabbitMQ, cfg.Messaging.Queues.UserActivity, cfg.Messaging.RabbitMQ.AutoAck, c.handle)
+	return c
+}
+
+// Start consumes the queue in the background until Stop is called
+func (c *Consumer) Start() {
+	c.consumer.Start()
+}
+
+// Stop cancels the consumer and waits for in-flight messages to be handled
+func (c *Consumer) Stop() {
+	c.consumer.Stop()
+}
+
+func (c *Consumer) handle(delivery amqp.Delivery) error {
+	var message models.ActivityMessage
+	if err := json.Unmarshal(delivery.Body, &message); err != nil {
+		return fmt.Errorf("%w: malformed activity message: %v", clients.ErrUnprocessableMessage, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	err := c.service.Store(ctx, &message)
+	if errors.Is(err, models.ErrInvalidActivityMessage) {
+		return fmt.Errorf("%w: %v", clients.ErrUnprocessableMessage, err)
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	GetUserActivity(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) GetUserActivity(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid 'from' parameter", "Expected RFC3339 timestamp")
+		return
+	}
+
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid 'to' parameter", "Expected RFC3339 timestamp")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req := &TimelineRequest{
+		UserID:      c.Param("id"),
+		Action:      c.Query("action"),
+		ServiceName: c.Query("service"),
+		From:        from,
+		To:          to,
+		Page:        page,
+		Limit:       limit,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": req.UserID,
+		"action":  req.Action,
+		"service": req.ServiceName,
+		"page":    req.Page,
+		"limit":   req.Limit,
+	}).Info("GetUserActivity request received")
+
+	response, err := h.service.GetTimeline(ctx, req)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", req.UserID).Error("Failed to get user activity")
+		if errors.Is(err, models.ErrInvalidParams) {
+			h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
+			return
+		}
+		h.sendErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve user activity", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+		"message": "User activity retrieved successfully",
+	})
+}
+
+func parseTimeParam(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
@@ -0,0 +1,75 @@
+package activity
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Record is a stored user activity event
+type Record struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID      string             `json:"userId" bson:"user_id"`
+	SessionID   string             `json:"sessionId,omitempty" bson:"session_id,omitempty"`
+	ServiceName string             `json:"serviceName" bson:"service_name"`
+	Action      string             `json:"action" bson:"action"`
+	IPAddress   string             `json:"ipAddress,omitempty" bson:"ip_address,omitempty"`
+	UserAgent   string             `json:"userAgent,omitempty" bson:"user_agent,omitempty"`
+	Metadata    map[string]string  `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	Timestamp   time.Time          `json:"timestamp" bson:"timestamp"`
+	ReceivedAt  time.Time          `json:"receivedAt" bson:"received_at"`
+}
+
+// TimelineRequest represents filters for a user activity timeline
+type TimelineRequest struct {
+	UserID      string
+	Action      string
+	ServiceName string
+	From        *time.Time
+	To          *time.Time
+	Page        int
+	Limit       int
+}
+
+// TimelineResponse represents a page of a user activity timeline
+type TimelineResponse struct {
+	Events     []*models.ActivityMessage `json:"events"`
+	TotalCount int64                     `json:"totalCount"`
+	Page       int                       `json:"page"`
+	Limit      int                       `json:"limit"`
+	TotalPages int                       `json:"totalPages"`
+}
+
+// ToMessage converts a stored Record back to the ActivityMessage shape
+func (r *Record) ToMessage() *models.ActivityMessage {
+	return &models.ActivityMessage{
+		UserID:      r.UserID,
+		SessionID:   r.SessionID,
+		ServiceName: r.ServiceName,
+		Action:      r.Action,
+		IPAddress:   r.IPAddress,
+		UserAgent:   r.UserAgent,
+		Metadata:    r.Metadata,
+		Timestamp:   r.Timestamp,
+	}
+}
+
+func newRecord(message *models.ActivityMessage, receivedAt time.Time) *Record {
+	timestamp := message.Timestamp
+	if timestamp.IsZero() {
+		timestamp = receivedAt
+	}
+
+	return &Record{
+		UserID:      message.UserID,
+		SessionID:   message.SessionID,
+		ServiceName: message.ServiceName,
+		Action:      message.Action,
+		IPAddress:   message.IPAddress,
+		UserAgent:   message.UserAgent,
+		Metadata:    message.Metadata,
+		Timestamp:   timestamp,
+		ReceivedAt:  receivedAt,
+	}
+}
@@ -0,0 +1,102 @@
+package activity
+
+import (
+	"context"
+	"handyhub-admin-svc/src/clients"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, record *Record) error
+	FindByUser(ctx context.Context, req *TimelineRequest) ([]*Record, int64, error)
+	DeleteByUser(ctx context.Context, userID string) (int64, error)
+}
+
+type activityRepository struct {
+	Collection mongo.Collection
+}
+
+func NewActivityRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &activityRepository{
+		Collection: collection,
+	}
+}
+
+func (r *activityRepository) Insert(ctx context.Context, record *Record) error {
+	result, err := r.Collection.InsertOne(ctx, record)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", record.UserID).Error("Failed to insert activity record")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		record.ID = id
+	}
+	return nil
+}
+
+// FindByUser returns a page of the user's activity, newest first
+func (r *activityRepository) FindByUser(ctx context.Context, req *TimelineRequest) ([]*Record, int64, error) {
+	filter := bson.M{"user_id": req.UserID}
+
+	if req.Action != "" {
+		filter["action"] = req.Action
+	}
+
+	if req.ServiceName != "" {
+		filter["service_name"] = req.ServiceName
+	}
+
+	if req.From != nil || req.To != nil {
+		timestamp := bson.M{}
+		if req.From != nil {
+			timestamp["$gte"] = *req.From
+		}
+		if req.To != nil {
+			timestamp["$lte"] = *req.To
+		}
+		filter["timestamp"] = timestamp
+	}
+
+	totalCount, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", req.UserID).Error("Failed to count activity records")
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.Limit)).
+		SetLimit(int64(req.Limit))
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", req.UserID).Error("Failed to find activity records")
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	records := make([]*Record, 0, req.Limit)
+	if err := cursor.All(ctx, &records); err != nil {
+		logrus.WithError(err).WithField("user_id", req.UserID).Error("Failed to decode activity records")
+		return nil, 0, err
+	}
+
+	return records, totalCount, nil
+}
+
+// DeleteByUser removes every activity record of the user
+func (r *activityRepository) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	result, err := r.Collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to delete activity records")
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
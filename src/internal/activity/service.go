@@ -0,0 +1,89 @@
+package activity
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Service interface {
+	Store(ctx context.Context, message *models.ActivityMessage) error
+	GetTimeline(ctx context.Context, req *TimelineRequest) (*TimelineResponse, error)
+	DeleteUserActivity(ctx context.Context, userID string) (int64, error)
+}
+
+type activityService struct {
+	activityRepository Repository
+	cfg                *config.Configuration
+}
+
+func NewActivityService(activityRepository Repository, cfg *config.Configuration) Service {
+	return &activityService{
+		activityRepository: activityRepository,
+		cfg:                cfg,
+	}
+}
+
+// Store validates and persists a consumed activity message
+func (s *activityService) Store(ctx context.Context, message *models.ActivityMessage) error {
+	if message.UserID == "" || message.Action == "" {
+		return models.ErrInvalidActivityMessage
+	}
+
+	record := newRecord(message, time.Now())
+	if err := s.activityRepository.Insert(ctx, record); err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": record.UserID, "service": record.ServiceName, "action": record.Action,
+	}).Debug("Activity record stored")
+
+	return nil
+}
+
+// GetTimeline returns a page of the user's stored activity, newest first
+func (s *activityService) GetTimeline(ctx context.Context, req *TimelineRequest) (*TimelineResponse, error) {
+	if _, err := primitive.ObjectIDFromHex(req.UserID); err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = s.cfg.Search.MinQueryLimit
+	}
+	if req.Limit > s.cfg.Search.MaxQueryLimit {
+		req.Limit = s.cfg.Search.MaxQueryLimit
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	records, totalCount, err := s.activityRepository.FindByUser(ctx, req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get activity timeline from repository")
+		return nil, err
+	}
+
+	events := make([]*models.ActivityMessage, len(records))
+	for i, record := range records {
+		events[i] = record.ToMessage()
+	}
+
+	return &TimelineResponse{
+		Events:     events,
+		TotalCount: totalCount,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(req.Limit))),
+	}, nil
+}
+
+// DeleteUserActivity erases the activity history of the user, which holds IP addresses
+func (s *activityService) DeleteUserActivity(ctx context.Context, userID string) (int64, error) {
+	return s.activityRepository.DeleteByUser(ctx, userID)
+}
@@ -0,0 +1,124 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	GetAPIKeys(c *gin.Context)
+	CreateAPIKey(c *gin.Context)
+	RevokeAPIKey(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) GetAPIKeys(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	logrus.Info("GetAPIKeys request received")
+
+	keys, err := h.service.List(ctx)
+	if err != nil {
+		h.handleError(c, err, "Failed to retrieve API keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    keys,
+		"message": "API keys retrieved successfully",
+	})
+}
+
+func (h *handler) CreateAPIKey(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Warn("Invalid create api key request body")
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", "Name and at least one scope are required")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"name": req.Name, "scopes": req.Scopes,
+	}).Info("CreateAPIKey request received")
+
+	response, err := h.service.Create(ctx, &req, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create API key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    response,
+		"message": "API key created successfully. Store the key now, it will not be shown again",
+	})
+}
+
+func (h *handler) RevokeAPIKey(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	keyID := c.Param("id")
+	logrus.WithField("key_id", keyID).Info("RevokeAPIKey request received")
+
+	key, err := h.service.Revoke(ctx, keyID, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    key,
+		"message": "API key revoked successfully",
+	})
+}
+
+func (h *handler) handleError(c *gin.Context, err error, message string) {
+	logrus.WithError(err).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Please provide a valid key ID and a future expiry")
+	case errors.Is(err, models.ErrInvalidAPIKeyScope):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid scope", err.Error())
+	case errors.Is(err, models.ErrAPIKeyScopeNotAllowed):
+		h.sendErrorResponse(c, http.StatusForbidden, "Scope not allowed", err.Error())
+	case errors.Is(err, models.ErrAPIKeyNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "API key not found", "No active API key found with the provided ID")
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
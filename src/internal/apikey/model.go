@@ -0,0 +1,53 @@
+package apikey
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// keyPrefix marks raw API keys issued by the admin service
+const keyPrefix = "hhk_"
+
+type APIKey struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name       string             `json:"name" bson:"name"`
+	Prefix     string             `json:"prefix" bson:"prefix"`
+	KeyHash    string             `json:"-" bson:"key_hash"`
+	Scopes     []string           `json:"scopes" bson:"scopes"`
+	CreatedBy  models.Actor       `json:"createdBy" bson:"created_by"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"created_at"`
+	ExpiresAt  *time.Time         `json:"expiresAt,omitempty" bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time         `json:"lastUsedAt,omitempty" bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time         `json:"revokedAt,omitempty" bson:"revoked_at,omitempty"`
+}
+
+// CreateRequest represents request body for issuing an API key
+type CreateRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreateResponse carries the raw key, which is shown only once
+type CreateResponse struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// IsUsable checks if key is neither revoked nor expired
+func (k *APIKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) toPrincipal() *models.APIKeyPrincipal {
+	return &models.APIKeyPrincipal{
+		KeyID:  k.ID.Hex(),
+		Name:   k.Name,
+		Scopes: k.Scopes,
+	}
+}
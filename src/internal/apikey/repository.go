@@ -0,0 +1,111 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// lastUsedResolution limits last-used writes to one per key per minute
+const lastUsedResolution = time.Minute
+
+type Repository interface {
+	Insert(ctx context.Context, key *APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	List(ctx context.Context) ([]*APIKey, error)
+	Revoke(ctx context.Context, id primitive.ObjectID, at time.Time) (*APIKey, error)
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error
+}
+
+type apiKeyRepository struct {
+	Collection mongo.Collection
+}
+
+func NewAPIKeyRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &apiKeyRepository{
+		Collection: collection,
+	}
+}
+
+func (r *apiKeyRepository) Insert(ctx context.Context, key *APIKey) error {
+	result, err := r.Collection.InsertOne(ctx, key)
+	if err != nil {
+		logrus.WithError(err).WithField("name", key.Name).Error("Failed to insert api key")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		key.ID = id
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	var key APIKey
+	if err := r.Collection.FindOne(ctx, bson.M{"key_hash": keyHash}).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrInvalidAPIKey
+		}
+		logrus.WithError(err).Error("Failed to find api key")
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*APIKey, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := r.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to list api keys")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := make([]*APIKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		logrus.WithError(err).Error("Failed to decode api keys")
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke marks a not yet revoked key as revoked and returns the updated key
+func (r *apiKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID, at time.Time) (*APIKey, error) {
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": at}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var key APIKey
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrAPIKeyNotFound
+		}
+		logrus.WithError(err).WithField("key_id", id.Hex()).Error("Failed to revoke api key")
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	filter := bson.M{
+		"_id": id,
+		"$or": []bson.M{
+			{"last_used_at": bson.M{"$exists": false}},
+			{"last_used_at": bson.M{"$lt": at.Add(-lastUsedResolution)}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"last_used_at": at}}
+
+	_, err := r.Collection.UpdateOne(ctx, filter, update)
+	return err
+}
@@ -0,0 +1,176 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/rbac"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const keyBytes = 32
+
+type Service interface {
+	Create(ctx context.Context, req *CreateRequest, actor *models.Actor) (*CreateResponse, error)
+	List(ctx context.Context) ([]*APIKey, error)
+	Revoke(ctx context.Context, id string, actor *models.Actor) (*APIKey, error)
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKeyPrincipal, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepository Repository
+	auditService     audit.Service
+	authorizer       *rbac.Authorizer
+	cfg              *config.Configuration
+}
+
+func NewAPIKeyService(apiKeyRepository Repository,
+	auditService audit.Service,
+	authorizer *rbac.Authorizer,
+	cfg *config.Configuration) Service {
+	return &apiKeyService{
+		apiKeyRepository: apiKeyRepository,
+		auditService:     auditService,
+		authorizer:       authorizer,
+		cfg:              cfg,
+	}
+}
+
+// Create issues a new API key. Scopes may not exceed the permissions of the creator.
+func (s *apiKeyService) Create(ctx context.Context, req *CreateRequest, actor *models.Actor) (*CreateResponse, error) {
+	if err := s.validateScopes(req.Scopes, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, models.ErrInvalidParams
+	}
+
+	rawKey, err := generateKey()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to generate api key")
+		return nil, err
+	}
+
+	key := &APIKey{
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    rawKey[:len(keyPrefix)+8],
+		KeyHash:   hashKey(rawKey),
+		Scopes:    req.Scopes,
+		CreatedBy: *actor,
+		CreatedAt: now,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := s.apiKeyRepository.Insert(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, audit.ActionCreateAPIKey, key, actor)
+
+	logrus.WithFields(logrus.Fields{
+		"key_id": key.ID.Hex(), "name": key.Name, "scopes": key.Scopes, "actor_id": actor.ID,
+	}).Info("API key created")
+
+	return &CreateResponse{APIKey: key, Key: rawKey}, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context) ([]*APIKey, error) {
+	return s.apiKeyRepository.List(ctx)
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, id string, actor *models.Actor) (*APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	key, err := s.apiKeyRepository.Revoke(ctx, objectID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, audit.ActionRevokeAPIKey, key, actor)
+
+	logrus.WithFields(logrus.Fields{
+		"key_id": id, "actor_id": actor.ID,
+	}).Info("API key revoked")
+
+	return key, nil
+}
+
+// Authenticate resolves a raw key into its principal and tracks its last usage
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*models.APIKeyPrincipal, error) {
+	if !strings.HasPrefix(rawKey, keyPrefix) {
+		return nil, models.ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepository.FindByHash(ctx, hashKey(rawKey))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, models.ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepository.TouchLastUsed(ctx, key.ID, now); err != nil {
+		logrus.WithError(err).WithField("key_id", key.ID.Hex()).Warn("Failed to update api key last usage")
+	}
+
+	return key.toPrincipal(), nil
+}
+
+func (s *apiKeyService) validateScopes(scopes []string, actor *models.Actor) error {
+	for _, scope := range scopes {
+		if !rbac.IsKnownPermission(scope) {
+			return models.ErrInvalidAPIKeyScope
+		}
+		if !s.authorizer.HasPermission(actor.Role, scope) {
+			return models.ErrAPIKeyScopeNotAllowed
+		}
+	}
+	return nil
+}
+
+func (s *apiKeyService) recordAudit(ctx context.Context, action string, key *APIKey, actor *models.Actor) {
+	entry := &audit.Entry{
+		Actor:  *actor,
+		Action: action,
+		Metadata: map[string]string{
+			"key_id": key.ID.Hex(),
+			"name":   key.Name,
+			"scopes": strings.Join(key.Scopes, ","),
+		},
+	}
+
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action": action,
+			"key_id": key.ID.Hex(),
+		}).Error("Failed to record audit entry")
+	}
+}
+
+func generateKey() (string, error) {
+	buf := make([]byte, keyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return keyPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
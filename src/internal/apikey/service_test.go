@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/rbac"
+	"testing"
+)
+
+func TestValidateScopes(t *testing.T) {
+	cfg := &config.Configuration{}
+	cfg.Security.RBAC.Roles = map[string][]string{
+		"superadmin": {rbac.PermAll},
+		"admin":      {rbac.PermUsersRead, rbac.PermStatsRead, rbac.PermAPIKeysManage},
+	}
+	s := &apiKeyService{authorizer: rbac.NewAuthorizer(cfg), cfg: cfg}
+
+	tests := []struct {
+		name   string
+		role   string
+		scopes []string
+		want   error
+	}{
+		{
+			name:   "scopes within the creator's permissions",
+			role:   "admin",
+			scopes: []string{rbac.PermUsersRead, rbac.PermStatsRead},
+		},
+		{
+			name:   "superadmin may grant any known permission",
+			role:   "superadmin",
+			scopes: []string{rbac.PermSessionsRevoke, rbac.PermAPIKeysManage},
+		},
+		{
+			name:   "superadmin may grant the wildcard",
+			role:   "superadmin",
+			scopes: []string{rbac.PermAll},
+		},
+		{
+			name:   "unknown scope",
+			role:   "superadmin",
+			scopes: []string{"users:everything"},
+			want:   models.ErrInvalidAPIKeyScope,
+		},
+		{
+			name:   "scope beyond the creator's permissions",
+			role:   "admin",
+			scopes: []string{rbac.PermUsersRead, rbac.PermUsersSuspend},
+			want:   models.ErrAPIKeyScopeNotAllowed,
+		},
+		{
+			name:   "wildcard beyond the creator's permissions",
+			role:   "admin",
+			scopes: []string{rbac.PermAll},
+			want:   models.ErrAPIKeyScopeNotAllowed,
+		},
+		{
+			name:   "unknown scope is reported before missing permissions",
+			role:   "admin",
+			scopes: []string{"stats:write"},
+			want:   models.ErrInvalidAPIKeyScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.validateScopes(tt.scopes, &models.Actor{ID: "creator", Role: tt.role})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("validateScopes(%v) error = %v, want %v", tt.scopes, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package approval
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/models"
+)
+
+// Executor applies an approved action. Domain packages register executors,
+// so this package does not depend on them.
+type Executor interface {
+	Execute(ctx context.Context, action *PendingAction, reviewer *models.Actor) error
+}
+
+// ExecutorFunc adapts a function to the Executor interface
+type ExecutorFunc func(ctx context.Context, action *PendingAction, reviewer *models.Actor) error
+
+func (f ExecutorFunc) Execute(ctx context.Context, action *PendingAction, reviewer *models.Actor) error {
+	return f(ctx, action, reviewer)
+}
+
+type registeredExecutor struct {
+	permission string
+	executor   Executor
+}
@@ -0,0 +1,158 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	GetApprovals(c *gin.Context)
+	GetApproval(c *gin.Context)
+	ApproveAction(c *gin.Context)
+	RejectAction(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) GetApprovals(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req := &ListRequest{
+		Status: c.DefaultQuery("status", StatusPending),
+		Limit:  limit,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"status": req.Status, "limit": req.Limit,
+	}).Info("GetApprovals request received")
+
+	actions, err := h.service.List(ctx, req)
+	if err != nil {
+		h.handleError(c, "", err, "Failed to retrieve approval requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    actions,
+		"message": "Approval requests retrieved successfully",
+	})
+}
+
+func (h *handler) GetApproval(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	approvalID := c.Param("id")
+	logrus.WithField("approval_id", approvalID).Info("GetApproval request received")
+
+	action, err := h.service.GetByID(ctx, approvalID)
+	if err != nil {
+		h.handleError(c, approvalID, err, "Failed to retrieve approval request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    action,
+		"message": "Approval request retrieved successfully",
+	})
+}
+
+func (h *handler) ApproveAction(c *gin.Context) {
+	h.reviewHandler(c, h.service.Approve, "Action approved and executed successfully")
+}
+
+func (h *handler) RejectAction(c *gin.Context) {
+	h.reviewHandler(c, h.service.Reject, "Action rejected successfully")
+}
+
+type reviewFunc func(ctx context.Context, id, comment string, reviewer *models.Actor) (*PendingAction, error)
+
+func (h *handler) reviewHandler(c *gin.Context, review reviewFunc, successMessage string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req ReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logrus.WithError(err).Warn("Invalid review request body")
+			h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+	}
+
+	approvalID := c.Param("id")
+	logrus.WithFields(logrus.Fields{
+		"approval_id": approvalID, "route": c.GetString("route_name"),
+	}).Info("Review approval request received")
+
+	action, err := review(ctx, approvalID, req.Comment, middleware.ActorFromContext(c))
+	if err != nil {
+		if action != nil {
+			// Approved, but the underlying operation failed
+			logrus.WithError(err).WithField("approval_id", approvalID).Error("Approved action failed")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":   "Approved action failed",
+				"success": false,
+				"data":    action,
+				"message": err.Error(),
+			})
+			return
+		}
+		h.handleError(c, approvalID, err, "Failed to review approval request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    action,
+		"message": successMessage,
+	})
+}
+
+func (h *handler) handleError(c *gin.Context, approvalID string, err error, message string) {
+	logrus.WithError(err).WithField("approval_id", approvalID).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Please provide a valid approval ID and status")
+	case errors.Is(err, models.ErrApprovalNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Approval request not found", "No approval request found with the provided ID")
+	case errors.Is(err, models.ErrApprovalNotPending), errors.Is(err, models.ErrApprovalExpired):
+		h.sendErrorResponse(c, http.StatusConflict, "Approval request cannot be reviewed", err.Error())
+	case errors.Is(err, models.ErrSelfApproval), errors.Is(err, models.ErrApprovalNotAllowed):
+		h.sendErrorResponse(c, http.StatusForbidden, "Review not permitted", err.Error())
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
@@ -0,0 +1,54 @@
+package approval
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Approval status constants
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+	StatusExpired  = "expired"
+	StatusFailed   = "failed"
+)
+
+// PendingAction is a high-impact change waiting for a second admin
+type PendingAction struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Action        string             `json:"action" bson:"action"`
+	TargetUserID  string             `json:"targetUserId,omitempty" bson:"target_user_id,omitempty"`
+	TargetEmail   string             `json:"targetEmail,omitempty" bson:"target_email,omitempty"`
+	TargetRole    string             `json:"targetRole,omitempty" bson:"target_role,omitempty"`
+	Params        map[string]string  `json:"params,omitempty" bson:"params,omitempty"`
+	Status        string             `json:"status" bson:"status"`
+	RequestedBy   models.Actor       `json:"requestedBy" bson:"requested_by"`
+	ReviewedBy    *models.Actor      `json:"reviewedBy,omitempty" bson:"reviewed_by,omitempty"`
+	ReviewComment string             `json:"reviewComment,omitempty" bson:"review_comment,omitempty"`
+	Error         string             `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"created_at"`
+	ExpiresAt     time.Time          `json:"expiresAt" bson:"expires_at"`
+	ReviewedAt    *time.Time         `json:"reviewedAt,omitempty" bson:"reviewed_at,omitempty"`
+}
+
+// ReviewRequest represents request body for approving or rejecting an action
+type ReviewRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ListRequest represents filters for listing approval requests
+type ListRequest struct {
+	Status string
+	Limit  int
+}
+
+func isValidStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusApproved, StatusRejected, StatusExpired, StatusFailed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, action *PendingAction) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*PendingAction, error)
+	FindPending(ctx context.Context, action, targetUserID string, now time.Time) (*PendingAction, error)
+	List(ctx context.Context, status string, limit int) ([]*PendingAction, error)
+	Review(ctx context.Context, id primitive.ObjectID, status string, reviewer *models.Actor, comment string, at time.Time) (*PendingAction, error)
+	SetStatus(ctx context.Context, id primitive.ObjectID, from, to, errMessage string) error
+	AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error)
+}
+
+type approvalRepository struct {
+	Collection mongo.Collection
+}
+
+func NewApprovalRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &approvalRepository{
+		Collection: collection,
+	}
+}
+
+func (r *approvalRepository) Insert(ctx context.Context, action *PendingAction) error {
+	result, err := r.Collection.InsertOne(ctx, action)
+	if err != nil {
+		logrus.WithError(err).WithField("action", action.Action).Error("Failed to insert approval request")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		action.ID = id
+	}
+	return nil
+}
+
+func (r *approvalRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*PendingAction, error) {
+	var action PendingAction
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&action); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrApprovalNotFound
+		}
+		logrus.WithError(err).WithField("approval_id", id.Hex()).Error("Failed to get approval request")
+		return nil, err
+	}
+	return &action, nil
+}
+
+// FindPending returns an unexpired pending request for the same action and target, if any
+func (r *approvalRepository) FindPending(ctx context.Context, action, targetUserID string, now time.Time) (*PendingAction, error) {
+	filter := bson.M{
+		"action":         action,
+		"target_user_id": targetUserID,
+		"status":         StatusPending,
+		"expires_at":     bson.M{"$gt": now},
+	}
+
+	var pending PendingAction
+	if err := r.Collection.FindOne(ctx, filter).Decode(&pending); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		logrus.WithError(err).WithField("target_user_id", targetUserID).Error("Failed to find pending approval request")
+		return nil, err
+	}
+	return &pending, nil
+}
+
+func (r *approvalRepository) List(ctx context.Context, status string, limit int) ([]*PendingAction, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"created_at": -1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to list approval requests")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	actions := make([]*PendingAction, 0)
+	if err := cursor.All(ctx, &actions); err != nil {
+		logrus.WithError(err).Error("Failed to decode approval requests")
+		return nil, err
+	}
+	return actions, nil
+}
+
+// Review moves a pending request to the given status; only one reviewer can win
+func (r *approvalRepository) Review(ctx context.Context, id primitive.ObjectID, status string, reviewer *models.Actor, comment string, at time.Time) (*PendingAction, error) {
+	filter := bson.M{"_id": id, "status": StatusPending}
+	update := bson.M{
+		"$set": bson.M{
+			"status":         status,
+			"reviewed_by":    reviewer,
+			"review_comment": comment,
+			"reviewed_at":    at,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var action PendingAction
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&action); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrApprovalNotPending
+		}
+		logrus.WithError(err).WithField("approval_id", id.Hex()).Error("Failed to review approval request")
+		return nil, err
+	}
+	return &action, nil
+}
+
+func (r *approvalRepository) SetStatus(ctx context.Context, id primitive.ObjectID, from, to, errMessage string) error {
+	set := bson.M{"status": to}
+	if errMessage != "" {
+		set["error"] = errMessage
+	}
+
+	_, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id, "status": from}, bson.M{"$set": set})
+	if err != nil {
+		logrus.WithError(err).WithField("approval_id", id.Hex()).Error("Failed to update approval request status")
+	}
+	return err
+}
+
+func (r *approvalRepository) AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error) {
+	filter := bson.M{
+		"target_user_id": userID,
+		"$or": []bson.M{
+			{"target_email": bson.M{"$exists": true}},
+			{"params.note": bson.M{"$exists": true}},
+		},
+	}
+	// Requests without an email keep having none, only the note is dropped from them
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"target_email": bson.M{
+			"$cond": bson.A{bson.M{"$ifNull": bson.A{"$target_email", false}}, placeholder, "$$REMOVE"},
+		}}}},
+		{{Key: "$unset", Value: "params.note"}},
+	}
+
+	result, err := r.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithField("target_user_id", userID).Error("Failed to anonymize approval requests")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,242 @@
+package approval
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/rbac"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Service interface {
+	RegisterExecutor(action, permission string, executor Executor)
+	RequiresApproval(action, targetRole string, actor *models.Actor) bool
+	Request(ctx context.Context, action *PendingAction) (*PendingAction, error)
+	List(ctx context.Context, req *ListRequest) ([]*PendingAction, error)
+	GetByID(ctx context.Context, id string) (*PendingAction, error)
+	Approve(ctx context.Context, id, comment string, reviewer *models.Actor) (*PendingAction, error)
+	Reject(ctx context.Context, id, comment string, reviewer *models.Actor) (*PendingAction, error)
+	AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error)
+}
+
+type approvalService struct {
+	approvalRepository Repository
+	auditService       audit.Service
+	authorizer         *rbac.Authorizer
+	executors          map[string]registeredExecutor
+	cfg                *config.Configuration
+}
+
+func NewApprovalService(approvalRepository Repository,
+	auditService audit.Service,
+	authorizer *rbac.Authorizer,
+	cfg *config.Configuration) Service {
+	return &approvalService{
+		approvalRepository: approvalRepository,
+		auditService:       auditService,
+		authorizer:         authorizer,
+		executors:          make(map[string]registeredExecutor),
+		cfg:                cfg,
+	}
+}
+
+// RegisterExecutor wires the operation run on approval; reviewers need the given permission.
+// Executors are registered during startup, before requests are served.
+func (s *approvalService) RegisterExecutor(action, permission string, executor Executor) {
+	s.executors[action] = registeredExecutor{permission: permission, executor: executor}
+}
+
+// RequiresApproval applies the configured four-eyes policy. Background jobs are never held back.
+func (s *approvalService) RequiresApproval(action, targetRole string, actor *models.Actor) bool {
+	policy := s.cfg.Approvals
+	if !policy.Enabled || actor.ID == models.SystemActorID {
+		return false
+	}
+	if _, ok := s.executors[action]; !ok {
+		return false
+	}
+	return contains(policy.Actions, action) && contains(policy.TargetRoles, targetRole)
+}
+
+// Request stores a pending action, reusing an open request for the same action and target.
+// Actions without a single target user, such as bulk updates, always get their own request.
+func (s *approvalService) Request(ctx context.Context, action *PendingAction) (*PendingAction, error) {
+	now := time.Now()
+
+	var existing *PendingAction
+	if action.TargetUserID != "" {
+		var err error
+		if existing, err = s.approvalRepository.FindPending(ctx, action.Action, action.TargetUserID, now); err != nil {
+			return nil, err
+		}
+	}
+	if existing != nil {
+		logrus.WithFields(logrus.Fields{
+			"approval_id": existing.ID.Hex(), "action": action.Action, "target_user_id": action.TargetUserID,
+		}).Info("Approval request already pending")
+		return existing, nil
+	}
+
+	action.Status = StatusPending
+	action.CreatedAt = now
+	action.ExpiresAt = now.Add(time.Duration(s.cfg.Approvals.ExpirationHours) * time.Hour)
+
+	if err := s.approvalRepository.Insert(ctx, action); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, audit.ActionRequestApproval, action, &action.RequestedBy)
+
+	logrus.WithFields(logrus.Fields{
+		"approval_id": action.ID.Hex(), "action": action.Action,
+		"target_user_id": action.TargetUserID, "actor_id": action.RequestedBy.ID,
+	}).Info("Approval request created")
+
+	return action, nil
+}
+
+func (s *approvalService) List(ctx context.Context, req *ListRequest) ([]*PendingAction, error) {
+	if req.Status != "" && !isValidStatus(req.Status) {
+		return nil, models.ErrInvalidParams
+	}
+	if req.Limit <= 0 {
+		req.Limit = s.cfg.Search.MinQueryLimit
+	}
+	if req.Limit > s.cfg.Search.MaxQueryLimit {
+		req.Limit = s.cfg.Search.MaxQueryLimit
+	}
+
+	return s.approvalRepository.List(ctx, req.Status, req.Limit)
+}
+
+func (s *approvalService) GetByID(ctx context.Context, id string) (*PendingAction, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+	return s.approvalRepository.GetByID(ctx, objectID)
+}
+
+// Approve marks the request approved and runs its executor. A failed execution
+// is stored on the request and returned to the reviewer.
+func (s *approvalService) Approve(ctx context.Context, id, comment string, reviewer *models.Actor) (*PendingAction, error) {
+	pending, err := s.checkReviewable(ctx, id, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, ok := s.executors[pending.Action]
+	if !ok {
+		return nil, models.ErrApprovalUnsupported
+	}
+	if !s.authorizer.HasPermission(reviewer.Role, registered.permission) {
+		return nil, models.ErrApprovalNotAllowed
+	}
+
+	approved, err := s.approvalRepository.Review(ctx, pending.ID, StatusApproved, reviewer, comment, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, audit.ActionApproveAction, approved, reviewer)
+
+	if err := registered.executor.Execute(ctx, approved, reviewer); err != nil {
+		logrus.WithError(err).WithField("approval_id", id).Error("Approved action failed to execute")
+		_ = s.approvalRepository.SetStatus(ctx, approved.ID, StatusApproved, StatusFailed, err.Error())
+		approved.Status = StatusFailed
+		approved.Error = err.Error()
+		return approved, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"approval_id": id, "action": approved.Action, "reviewer_id": reviewer.ID,
+	}).Info("Approval request approved and executed")
+
+	return approved, nil
+}
+
+func (s *approvalService) Reject(ctx context.Context, id, comment string, reviewer *models.Actor) (*PendingAction, error) {
+	pending, err := s.checkReviewable(ctx, id, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := s.approvalRepository.Review(ctx, pending.ID, StatusRejected, reviewer, comment, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, audit.ActionRejectAction, rejected, reviewer)
+
+	logrus.WithFields(logrus.Fields{
+		"approval_id": id, "action": rejected.Action, "reviewer_id": reviewer.ID,
+	}).Info("Approval request rejected")
+
+	return rejected, nil
+}
+
+// AnonymizeTarget removes the email and free-text notes about an erased user from approval requests
+func (s *approvalService) AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error) {
+	return s.approvalRepository.AnonymizeTarget(ctx, userID, placeholder)
+}
+
+// checkReviewable loads a pending request and enforces the four-eyes rule
+func (s *approvalService) checkReviewable(ctx context.Context, id string, reviewer *models.Actor) (*PendingAction, error) {
+	pending, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending.Status != StatusPending {
+		return nil, models.ErrApprovalNotPending
+	}
+
+	if pending.RequestedBy.ID == reviewer.ID {
+		return nil, models.ErrSelfApproval
+	}
+
+	if !time.Now().Before(pending.ExpiresAt) {
+		_ = s.approvalRepository.SetStatus(ctx, pending.ID, StatusPending, StatusExpired, "")
+		return nil, models.ErrApprovalExpired
+	}
+
+	return pending, nil
+}
+
+// recordAudit writes an audit entry; failures are logged rather than returned
+func (s *approvalService) recordAudit(ctx context.Context, action string, pending *PendingAction, actor *models.Actor) {
+	entry := &audit.Entry{
+		Actor:        *actor,
+		Action:       action,
+		TargetUserID: pending.TargetUserID,
+		TargetEmail:  pending.TargetEmail,
+		TargetRole:   pending.TargetRole,
+		Metadata: map[string]string{
+			"approval_id":      pending.ID.Hex(),
+			"requested_action": pending.Action,
+		},
+	}
+	if pending.ReviewComment != "" {
+		entry.Metadata["comment"] = pending.ReviewComment
+	}
+
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":      action,
+			"approval_id": pending.ID.Hex(),
+		}).Error("Failed to record audit entry")
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/rbac"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeRepository keeps approval requests in memory; only the methods used by reviews are implemented
+type fakeRepository struct {
+	Repository
+	actions map[primitive.ObjectID]*PendingAction
+}
+
+func (r *fakeRepository) GetByID(_ context.Context, id primitive.ObjectID) (*PendingAction, error) {
+	action, ok := r.actions[id]
+	if !ok {
+		return nil, models.ErrApprovalNotFound
+	}
+	copied := *action
+	return &copied, nil
+}
+
+func (r *fakeRepository) Review(_ context.Context, id primitive.ObjectID, status string, reviewer *models.Actor, comment string, at time.Time) (*PendingAction, error) {
+	action, ok := r.actions[id]
+	if !ok || action.Status != StatusPending {
+		return nil, models.ErrApprovalNotPending
+	}
+	action.Status = status
+	action.ReviewedBy = reviewer
+	action.ReviewComment = comment
+	action.ReviewedAt = &at
+	copied := *action
+	return &copied, nil
+}
+
+func (r *fakeRepository) SetStatus(_ context.Context, id primitive.ObjectID, from, to, errMessage string) error {
+	if action, ok := r.actions[id]; ok && action.Status == from {
+		action.Status = to
+		action.Error = errMessage
+	}
+	return nil
+}
+
+// fakeAuditService drops audit entries
+type fakeAuditService struct {
+	audit.Service
+}
+
+func (s *fakeAuditService) Record(context.Context, *audit.Entry) error {
+	return nil
+}
+
+func newTestApprovalService(repository Repository) *approvalService {
+	cfg := &config.Configuration{}
+	cfg.Security.RBAC.Roles = map[string][]string{
+		"admin":   {rbac.PermUsersSuspend, rbac.PermApprovalsReview},
+		"support": {rbac.PermApprovalsReview},
+	}
+	cfg.Approvals = config.ApprovalsConfig{
+		Enabled:         true,
+		Actions:         []string{audit.ActionSuspendUser, audit.ActionDeactivateUser},
+		TargetRoles:     []string{"executor"},
+		ExpirationHours: 24,
+	}
+
+	s := NewApprovalService(repository, &fakeAuditService{}, rbac.NewAuthorizer(cfg), cfg).(*approvalService)
+	return s
+}
+
+func TestApprove(t *testing.T) {
+	requester := models.Actor{ID: "requester", Role: "admin"}
+	reviewer := &models.Actor{ID: "reviewer", Role: "admin"}
+	executeErr := errors.New("user changed meanwhile")
+
+	tests := []struct {
+		name       string
+		action     PendingAction
+		reviewer   *models.Actor
+		executeErr error
+		wantErr    error
+		wantStatus string
+		wantRun    bool
+	}{
+		{
+			name:       "second admin approves and the action runs",
+			action:     PendingAction{Action: audit.ActionSuspendUser, Status: StatusPending},
+			wantStatus: StatusApproved,
+			wantRun:    true,
+		},
+		{
+			name:       "requester cannot approve their own request",
+			action:     PendingAction{Action: audit.ActionSuspendUser, Status: StatusPending},
+			reviewer:   &models.Actor{ID: requester.ID, Role: "admin"},
+			wantErr:    models.ErrSelfApproval,
+			wantStatus: StatusPending,
+		},
+		{
+			name:       "expired request is marked expired",
+			action:     PendingAction{Action: audit.ActionSuspendUser, Status: StatusPending, ExpiresAt: time.Now().Add(-time.Minute)},
+			wantErr:    models.ErrApprovalExpired,
+			wantStatus: StatusExpired,
+		},
+		{
+			name:       "reviewed request cannot be approved again",
+			action:     PendingAction{Action: audit.ActionSuspendUser, Status: StatusRejected},
+			wantErr:    models.ErrApprovalNotPending,
+			wantStatus: StatusRejected,
+		},
+		{
+			name:       "reviewer needs the permission of the action",
+			action:     PendingAction{Action: audit.ActionSuspendUser, Status: StatusPending},
+			reviewer:   &models.Actor{ID: "reviewer", Role: "support"},
+			wantErr:    models.ErrApprovalNotAllowed,
+			wantStatus: StatusPending,
+		},
+		{
+			name:       "action without an executor",
+			action:     PendingAction{Action: audit.ActionDeactivateUser, Status: StatusPending},
+			wantErr:    models.ErrApprovalUnsupported,
+			wantStatus: StatusPending,
+		},
+		{
+			name:       "failed execution is stored on the request",
+			action:     PendingAction{Action: audit.ActionSuspendUser, Status: StatusPending},
+			executeErr: executeErr,
+			wantErr:    executeErr,
+			wantStatus: StatusFailed,
+			wantRun:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := tt.action
+			action.ID = primitive.NewObjectID()
+			action.RequestedBy = requester
+			if action.ExpiresAt.IsZero() {
+				action.ExpiresAt = time.Now().Add(time.Hour)
+			}
+			repository := &fakeRepository{actions: map[primitive.ObjectID]*PendingAction{action.ID: &action}}
+
+			s := newTestApprovalService(repository)
+			ran := false
+			s.RegisterExecutor(audit.ActionSuspendUser, rbac.PermUsersSuspend,
+				ExecutorFunc(func(context.Context, *PendingAction, *models.Actor) error {
+					ran = true
+					return tt.executeErr
+				}))
+
+			actor := tt.reviewer
+			if actor == nil {
+				actor = reviewer
+			}
+
+			_, err := s.Approve(context.Background(), action.ID.Hex(), "", actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Approve() error = %v, want %v", err, tt.wantErr)
+			}
+			if ran != tt.wantRun {
+				t.Errorf("executor ran = %v, want %v", ran, tt.wantRun)
+			}
+			if got := repository.actions[action.ID].Status; got != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRejectBySelf(t *testing.T) {
+	action := &PendingAction{
+		ID:          primitive.NewObjectID(),
+		Action:      audit.ActionSuspendUser,
+		Status:      StatusPending,
+		RequestedBy: models.Actor{ID: "requester", Role: "admin"},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	repository := &fakeRepository{actions: map[primitive.ObjectID]*PendingAction{action.ID: action}}
+	s := newTestApprovalService(repository)
+
+	_, err := s.Reject(context.Background(), action.ID.Hex(), "", &models.Actor{ID: "requester", Role: "admin"})
+	if !errors.Is(err, models.ErrSelfApproval) {
+		t.Fatalf("Reject() error = %v, want %v", err, models.ErrSelfApproval)
+	}
+	if action.Status != StatusPending {
+		t.Errorf("status = %q, want %q", action.Status, StatusPending)
+	}
+}
+
+func TestRequiresApproval(t *testing.T) {
+	s := newTestApprovalService(&fakeRepository{})
+	s.RegisterExecutor(audit.ActionSuspendUser, rbac.PermUsersSuspend,
+		ExecutorFunc(func(context.Context, *PendingAction, *models.Actor) error { return nil }))
+	admin := &models.Actor{ID: "admin", Role: "admin"}
+
+	tests := []struct {
+		name       string
+		action     string
+		targetRole string
+		actor      *models.Actor
+		disabled   bool
+		want       bool
+	}{
+		{name: "configured action and target role", action: audit.ActionSuspendUser, targetRole: "executor", actor: admin, want: true},
+		{name: "target role not covered", action: audit.ActionSuspendUser, targetRole: "client", actor: admin},
+		{name: "action not configured", action: audit.ActionActivateUser, targetRole: "executor", actor: admin},
+		{name: "configured action without executor", action: audit.ActionDeactivateUser, targetRole: "executor", actor: admin},
+		{name: "system actor is never held back", action: audit.ActionSuspendUser, targetRole: "executor", actor: &models.Actor{ID: models.SystemActorID}},
+		{name: "policy disabled", action: audit.ActionSuspendUser, targetRole: "executor", actor: admin, disabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.cfg.Approvals.Enabled = !tt.disabled
+			if got := s.RequiresApproval(tt.action, tt.targetRole, tt.actor); got != tt.want {
+				t.Errorf("RequiresApproval(%q, %q) = %v, want %v", tt.action, tt.targetRole, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	GetAuditEntries(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) GetAuditEntries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid 'from' parameter", "Expected RFC3339 timestamp")
+		return
+	}
+
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid 'to' parameter", "Expected RFC3339 timestamp")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req := &ListRequest{
+		ActorID:      c.Query("actorId"),
+		TargetUserID: c.Query("targetUserId"),
+		Action:       c.Query("action"),
+		From:         from,
+		To:           to,
+		Cursor:       c.Query("cursor"),
+		Limit:        limit,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"actor_id":       req.ActorID,
+		"target_user_id": req.TargetUserID,
+		"action":         req.Action,
+		"cursor":         req.Cursor,
+		"limit":          req.Limit,
+	}).Info("GetAuditEntries request received")
+
+	response, err := h.service.List(ctx, req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get audit entries")
+		if errors.Is(err, models.ErrInvalidParams) {
+			h.sendErrorResponse(c, http.StatusBadRequest, "Invalid cursor", "Please provide a cursor returned by a previous page")
+			return
+		}
+		h.sendErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve audit entries", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+		"message": "Audit entries retrieved successfully",
+	})
+}
+
+func parseTimeParam(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Entry struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Actor        models.Actor       `json:"actor" bson:"actor"`
+	Action       string             `json:"action" bson:"action"`
+	TargetUserID string             `json:"targetUserId" bson:"target_user_id"`
+	TargetEmail  string             `json:"targetEmail,omitempty" bson:"target_email,omitempty"`
+	TargetRole   string             `json:"targetRole,omitempty" bson:"target_role,omitempty"`
+	BeforeStatus string             `json:"beforeStatus,omitempty" bson:"before_status,omitempty"`
+	AfterStatus  string             `json:"afterStatus,omitempty" bson:"after_status,omitempty"`
+	Metadata     map[string]string  `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"created_at"`
+}
+
+// Audit action constants
+const (
+	ActionActivateUser   = "activate_user"
+	ActionDeactivateUser = "deactivate_user"
+	ActionSuspendUser    = "suspend_user"
+	ActionChangeRole     = "change_role"
+	ActionDeleteUser     = "delete_user"
+	ActionRestoreUser    = "restore_user"
+	ActionBulkStatus     = "bulk_update_status"
+
+	ActionRequestErasure = "request_erasure"
+	ActionCancelErasure  = "cancel_erasure"
+	ActionEraseUser      = "erase_user"
+
+	ActionExportUser     = "export_user"
+	ActionDownloadExport = "download_export"
+
+	ActionSuspensionExpired = "suspension_expired"
+
+	ActionTerminateSession     = "terminate_session"
+	ActionTerminateAllSessions = "terminate_all_sessions"
+
+	ActionCreateAPIKey = "create_api_key"
+	ActionRevokeAPIKey = "revoke_api_key"
+
+	ActionRequestApproval = "request_approval"
+	ActionApproveAction   = "approve_action"
+	ActionRejectAction    = "reject_action"
+
+	ActionReplayDeadLetter = "replay_dead_letter"
+	ActionPurgeDeadLetters = "purge_dead_letters"
+)
+
+// ListRequest represents filters for listing audit entries
+type ListRequest struct {
+	ActorID      string
+	TargetUserID string
+	Action       string
+	From         *time.Time
+	To           *time.Time
+	Cursor       string
+	Limit        int
+}
+
+// ListResponse represents a page of audit entries
+type ListResponse struct {
+	Entries    []*Entry `json:"entries"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+	Limit      int      `json:"limit"`
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"context"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, entry *Entry) error
+	List(ctx context.Context, req *ListRequest, cursorID *primitive.ObjectID) ([]*Entry, error)
+	GetStatusChangeSeries(ctx context.Context, query *models.TimeSeriesQuery, statuses []string) ([]models.TimeSeriesResult, error)
+	AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error)
+}
+
+type auditRepository struct {
+	Collection mongo.Collection
+}
+
+func NewAuditRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &auditRepository{
+		Collection: collection,
+	}
+}
+
+func (r *auditRepository) Insert(ctx context.Context, entry *Entry) error {
+	result, err := r.Collection.InsertOne(ctx, entry)
+	if err != nil {
+		logrus.WithError(err).WithField("action", entry.Action).Error("Failed to insert audit entry")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = id
+	}
+	return nil
+}
+
+// List returns entries newest first, starting after cursorID when provided.
+// One extra entry is fetched so the caller can tell whether another page exists.
+func (r *auditRepository) List(ctx context.Context, req *ListRequest, cursorID *primitive.ObjectID) ([]*Entry, error) {
+	filter := bson.M{}
+
+	if req.ActorID != "" {
+		filter["actor.id"] = req.ActorID
+	}
+
+	if req.TargetUserID != "" {
+		filter["target_user_id"] = req.TargetUserID
+	}
+
+	if req.Action != "" {
+		filter["action"] = req.Action
+	}
+
+	if req.From != nil || req.To != nil {
+		createdAt := bson.M{}
+		if req.From != nil {
+			createdAt["$gte"] = *req.From
+		}
+		if req.To != nil {
+			createdAt["$lte"] = *req.To
+		}
+		filter["created_at"] = createdAt
+	}
+
+	if cursorID != nil {
+		filter["_id"] = bson.M{"$lt": *cursorID}
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"_id": -1}).
+		SetLimit(int64(req.Limit + 1))
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find audit entries")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := make([]*Entry, 0, req.Limit+1)
+	if err := cursor.All(ctx, &entries); err != nil {
+		logrus.WithError(err).Error("Failed to decode audit entries")
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetStatusChangeSeries counts status changes into the given statuses per interval bucket,
+// target role and resulting status
+func (r *auditRepository) GetStatusChangeSeries(ctx context.Context, query *models.TimeSeriesQuery, statuses []string) ([]models.TimeSeriesResult, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"created_at":   bson.M{"$gte": query.From, "$lt": query.To},
+			"after_status": bson.M{"$in": statuses},
+			"$expr":        bson.M{"$ne": bson.A{"$before_status", "$after_status"}},
+		}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"bucket": bson.M{"$dateTrunc": bson.M{
+					"date": "$created_at", "unit": query.Interval, "startOfWeek": "monday",
+				}},
+				"role":   "$target_role",
+				"status": "$after_status",
+			},
+			"count": bson.M{"$sum": 1},
+		}},
+		{"$project": bson.M{
+			"_id":    0,
+			"bucket": "$_id.bucket",
+			"role":   "$_id.role",
+			"status": "$_id.status",
+			"count":  1,
+		}},
+	}
+
+	cursor, err := r.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to execute aggregation for status change series")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []models.TimeSeriesResult
+	if err := cursor.All(ctx, &results); err != nil {
+		logrus.WithError(err).Error("Failed to decode status change series")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// AnonymizeTarget replaces the stored email of the target user and drops the suspension
+// note copied into the metadata, in every entry
+func (r *auditRepository) AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error) {
+	filter := bson.M{
+		"target_user_id": userID,
+		"$or": []bson.M{
+			{"target_email": bson.M{"$exists": true}},
+			{"metadata.note": bson.M{"$exists": true}},
+		},
+	}
+
+	// Entries without an email keep having none, only the note is dropped from them
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"target_email": bson.M{
+			"$cond": bson.A{bson.M{"$ifNull": bson.A{"$target_email", false}}, placeholder, "$$REMOVE"},
+		}}}},
+		{{Key: "$unset", Value: "metadata.note"}},
+	}
+
+	result, err := r.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithField("target_user_id", userID).Error("Failed to anonymize audit entries")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Service interface {
+	Record(ctx context.Context, entry *Entry) error
+	List(ctx context.Context, req *ListRequest) (*ListResponse, error)
+	GetStatusChangeSeries(ctx context.Context, query *models.TimeSeriesQuery, statuses []string) ([]models.TimeSeriesResult, error)
+	AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error)
+}
+
+type auditService struct {
+	auditRepository Repository
+	cfg             *config.Configuration
+}
+
+func NewAuditService(auditRepository Repository, cfg *config.Configuration) Service {
+	return &auditService{
+		auditRepository: auditRepository,
+		cfg:             cfg,
+	}
+}
+
+// Record persists an audit entry for an admin mutation
+func (s *auditService) Record(ctx context.Context, entry *Entry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := s.auditRepository.Insert(ctx, entry); err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"actor_id":       entry.Actor.ID,
+		"action":         entry.Action,
+		"target_user_id": entry.TargetUserID,
+		"before_status":  entry.BeforeStatus,
+		"after_status":   entry.AfterStatus,
+	}).Info("Audit entry recorded")
+
+	return nil
+}
+
+// List returns a page of audit entries matching the request filters
+func (s *auditService) List(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	if req.Limit <= 0 {
+		req.Limit = s.cfg.Search.MinQueryLimit
+	}
+	if req.Limit > s.cfg.Search.MaxQueryLimit {
+		req.Limit = s.cfg.Search.MaxQueryLimit
+	}
+
+	var cursorID *primitive.ObjectID
+	if req.Cursor != "" {
+		id, err := primitive.ObjectIDFromHex(req.Cursor)
+		if err != nil {
+			return nil, models.ErrInvalidParams
+		}
+		cursorID = &id
+	}
+
+	entries, err := s.auditRepository.List(ctx, req, cursorID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get audit entries from repository")
+		return nil, err
+	}
+
+	response := &ListResponse{Entries: entries, Limit: req.Limit}
+	if len(entries) > req.Limit {
+		response.Entries = entries[:req.Limit]
+		response.NextCursor = response.Entries[req.Limit-1].ID.Hex()
+	}
+
+	return response, nil
+}
+
+// GetStatusChangeSeries returns bucketed counts of status changes into the given statuses
+func (s *auditService) GetStatusChangeSeries(ctx context.Context, query *models.TimeSeriesQuery, statuses []string) ([]models.TimeSeriesResult, error) {
+	results, err := s.auditRepository.GetStatusChangeSeries(ctx, query, statuses)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get status change series from repository")
+		return nil, err
+	}
+	return results, nil
+}
+
+// AnonymizeTarget removes the email and free-text notes about an erased user from the audit trail, keeping the entries
+func (s *auditService) AnonymizeTarget(ctx context.Context, userID, placeholder string) (int64, error) {
+	return s.auditRepository.AnonymizeTarget(ctx, userID, placeholder)
+}
@@ -19,8 +19,19 @@ type Service interface {
 	CacheActiveSession(ctx context.Context, session *models.Session) error
 	SaveUserStats(ctx context.Context, stats *models.Stats) error
 	GetUserStats(ctx context.Context) (*models.Stats, error)
+	SaveUserStatsTimeSeries(ctx context.Context, query *models.TimeSeriesQuery, series *models.TimeSeries) error
+	GetUserStatsTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error)
+	InvalidateUserStats(ctx context.Context) error
+	DeleteUserSessions(ctx context.Context, userID string) (int64, error)
+	DeleteSession(ctx context.Context, userID, sessionID string) error
 }
 
+const (
+	sessionKeyPattern    = "session:%s:%s" // session:userID:sessionID
+	timeSeriesKeyPattern = "%s:%s:%d:%d"   // prefix:interval:from:to
+	scanBatchSize        = 100
+)
+
 type cacheService struct {
 	client *redis.Client
 	cfg    *config.CacheConfig
@@ -86,7 +97,7 @@ func (c *cacheService) UpdateSessionActivity(ctx context.Context, key string) er
 }
 
 func (c *cacheService) CacheActiveSession(ctx context.Context, session *models.Session) error {
-	key := fmt.Sprintf("session:%s:%s", session.SessionID, session.SessionID)
+	key := fmt.Sprintf(sessionKeyPattern, session.UserID, session.SessionID)
 
 	data, err := json.Marshal(session)
 	if err != nil {
@@ -146,3 +157,109 @@ func (c *cacheService) GetUserStats(ctx context.Context) (*models.Stats, error)
 	logrus.Debug("User stats retrieved from cache successfully")
 	return &stats, nil
 }
+
+func (c *cacheService) SaveUserStatsTimeSeries(ctx context.Context, query *models.TimeSeriesQuery, series *models.TimeSeries) error {
+	data, err := json.Marshal(series)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to marshal user stats time series for cache")
+		return models.ErrRedisSet
+	}
+
+	key := c.timeSeriesKey(query)
+	expiration := time.Minute * time.Duration(c.cfg.UserStats.ExpirationMinutes)
+	if err := c.client.Set(ctx, key, data, expiration).Err(); err != nil {
+		logrus.WithError(err).WithField("key", key).Error("Failed to cache user stats time series")
+		return models.ErrRedisSet
+	}
+	return nil
+}
+
+func (c *cacheService) GetUserStatsTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	key := c.timeSeriesKey(query)
+
+	data, err := c.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			logrus.WithField("key", key).Debug("User stats time series not found in cache")
+			return nil, nil // Not an error, just not found
+		}
+		logrus.WithError(err).WithField("key", key).Error("Failed to get user stats time series from cache")
+		return nil, models.ErrRedisGet
+	}
+
+	var series models.TimeSeries
+	if err := json.Unmarshal([]byte(data), &series); err != nil {
+		logrus.WithError(err).WithField("key", key).Error("Failed to unmarshal user stats time series from cache")
+		return nil, models.ErrRedisGet
+	}
+
+	return &series, nil
+}
+
+// InvalidateUserStats drops the cached totals and every cached time series,
+// so the next stats request recomputes them from the database
+func (c *cacheService) InvalidateUserStats(ctx context.Context) error {
+	keys := []string{c.cfg.UserStats.Key}
+
+	iter := c.client.Scan(ctx, 0, c.cfg.UserStats.TimeSeriesKey+":*", scanBatchSize).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	if err := iter.Err(); err != nil {
+		logrus.WithError(err).Error("Failed to scan cached user stats time series")
+		return models.ErrRedisGet
+	}
+
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		logrus.WithError(err).Error("Failed to invalidate cached user stats")
+		return models.ErrRedisDelete
+	}
+
+	logrus.WithField("keys", len(keys)).Debug("User stats cache invalidated")
+	return nil
+}
+
+func (c *cacheService) timeSeriesKey(query *models.TimeSeriesQuery) string {
+	return fmt.Sprintf(timeSeriesKeyPattern,
+		c.cfg.UserStats.TimeSeriesKey, query.Interval, query.From.Unix(), query.To.Unix())
+}
+
+// DeleteUserSessions removes every cached session of the user so revoked tokens
+// are re-validated against the auth service on the next request
+func (c *cacheService) DeleteUserSessions(ctx context.Context, userID string) (int64, error) {
+	pattern := fmt.Sprintf(sessionKeyPattern, userID, "*")
+
+	var deleted int64
+	iter := c.client.Scan(ctx, 0, pattern, scanBatchSize).Iterator()
+	for iter.Next(ctx) {
+		n, err := c.client.Del(ctx, iter.Val()).Result()
+		if err != nil {
+			logrus.WithError(err).WithField("key", iter.Val()).Error("Failed to delete cached session")
+			return deleted, models.ErrRedisDelete
+		}
+		deleted += n
+	}
+
+	if err := iter.Err(); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to scan cached sessions")
+		return deleted, models.ErrRedisGet
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "deleted": deleted,
+	}).Debug("Cached user sessions deleted")
+	return deleted, nil
+}
+
+func (c *cacheService) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	key := fmt.Sprintf(sessionKeyPattern, userID, sessionID)
+
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		logrus.WithError(err).WithField("key", key).Error("Failed to delete cached session")
+		return models.ErrRedisDelete
+	}
+
+	logrus.WithField("key", key).Debug("Cached session deleted")
+	return nil
+}
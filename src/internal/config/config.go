@@ -19,6 +19,9 @@ type Configuration struct {
 	Cache            CacheConfig      `mapstructure:"cache"`
 	Search           SearchConfig     `mapstructure:"search"`
 	ExternalServices ExternalServices `mapstructure:"external-services"`
+	Jobs             JobsConfig       `mapstructure:"jobs"`
+	Approvals        ApprovalsConfig  `mapstructure:"approvals"`
+	Exports          ExportsConfig    `mapstructure:"exports"`
 }
 
 type Application struct {
@@ -35,11 +38,12 @@ type LogsSettings struct {
 }
 
 type ServerSettings struct {
-	Port         string `mapstructure:"port"`
-	Mode         string `mapstructure:"mode"`
-	ReadTimeout  int    `mapstructure:"read-timeout"`
-	WriteTimeout int    `mapstructure:"write-timeout"`
-	IdleTimeout  int    `mapstructure:"idle-timeout"`
+	Port            string `mapstructure:"port"`
+	Mode            string `mapstructure:"mode"`
+	ReadTimeout     int    `mapstructure:"read-timeout"`
+	WriteTimeout    int    `mapstructure:"write-timeout"`
+	IdleTimeout     int    `mapstructure:"idle-timeout"`
+	ShutdownTimeout int    `mapstructure:"shutdown-timeout"`
 }
 
 type Database struct {
@@ -50,8 +54,20 @@ type Database struct {
 }
 
 type DatabaseCollections struct {
-	Users    string `mapstructure:"users"`
-	Sessions string `mapstructure:"sessions"`
+	Users     string `mapstructure:"users"`
+	Sessions  string `mapstructure:"sessions"`
+	Audit     string `mapstructure:"audit"`
+	Activity  string `mapstructure:"activity"`
+	APIKeys   string `mapstructure:"api-keys"`
+	Approvals string `mapstructure:"approvals"`
+	BulkJobs  string `mapstructure:"bulk-jobs"`
+
+	ErasureRequests     string `mapstructure:"erasure-requests"`
+	ErasureCertificates string `mapstructure:"erasure-certificates"`
+	Exports             string `mapstructure:"exports"`
+	Outbox              string `mapstructure:"outbox"`
+	OutboxLeases        string `mapstructure:"outbox-leases"`
+	DeadLetters         string `mapstructure:"dead-letters"`
 }
 
 type Redis struct {
@@ -61,40 +77,89 @@ type Redis struct {
 }
 
 type MessagingConfig struct {
-	RabbitMQ RabbitMQConfig `mapstructure:"rabbitmq"`
-	Queues   QueuesConfig   `mapstructure:"queues"`
+	RabbitMQ          RabbitMQConfig          `mapstructure:"rabbitmq"`
+	Queues            QueuesConfig            `mapstructure:"queues"`
+	ActivityPublisher ActivityPublisherConfig `mapstructure:"activity-publisher"`
+}
+
+type ActivityPublisherConfig struct {
+	BufferSize      int    `mapstructure:"buffer-size"`
+	BatchSize       int    `mapstructure:"batch-size"`
+	FlushIntervalMs int    `mapstructure:"flush-interval-ms"`
+	SpillFile       string `mapstructure:"spill-file"`
 }
 
 type RabbitMQConfig struct {
-	Url            string `mapstructure:"url"`
-	Exchange       string `mapstructure:"exchange"`
-	ExchangeType   string `mapstructure:"exchange-type"`
-	PrefetchCount  int    `mapstructure:"prefetch-count"`
-	PrefetchSize   int    `mapstructure:"prefetch-size"`
-	Global         bool   `mapstructure:"global"`
-	ReconnectDelay int    `mapstructure:"reconnect-delay"`
-	Timeout        int    `mapstructure:"timeout"`
-	Durable        bool   `mapstructure:"durable"`
-	AutoDelete     bool   `mapstructure:"auto-delete"`
-	Internal       bool   `mapstructure:"internal"`
-	NoWait         bool   `mapstructure:"no-wait"`
-	Exclusive      bool   `mapstructure:"exclusive"`
-	AutoAck        bool   `mapstructure:"auto-ack"`
-	NoLocal        bool   `mapstructure:"no-local"`
+	Url               string `mapstructure:"url"`
+	Exchange          string `mapstructure:"exchange"`
+	ExchangeType      string `mapstructure:"exchange-type"`
+	PrefetchCount     int    `mapstructure:"prefetch-count"`
+	PrefetchSize      int    `mapstructure:"prefetch-size"`
+	Global            bool   `mapstructure:"global"`
+	ReconnectDelay    int    `mapstructure:"reconnect-delay"`
+	MaxReconnectDelay int    `mapstructure:"max-reconnect-delay"`
+	Timeout           int    `mapstructure:"timeout"`
+	Durable           bool   `mapstructure:"durable"`
+	AutoDelete        bool   `mapstructure:"auto-delete"`
+	Internal          bool   `mapstructure:"internal"`
+	NoWait            bool   `mapstructure:"no-wait"`
+	Exclusive         bool   `mapstructure:"exclusive"`
+	AutoAck           bool   `mapstructure:"auto-ack"`
+	NoLocal           bool   `mapstructure:"no-local"`
+
+	DeadLetterExchange string `mapstructure:"dead-letter-exchange"`
 }
 
 type QueuesConfig struct {
-	UserActivity QueueConfig `mapstructure:"user-activity"`
+	UserActivity    QueueConfig `mapstructure:"user-activity"`
+	SessionsRevoked QueueConfig `mapstructure:"sessions-revoked"`
+	RoleChanged     QueueConfig `mapstructure:"role-changed"`
+
+	UserActivated   QueueConfig `mapstructure:"user-activated"`
+	UserDeactivated QueueConfig `mapstructure:"user-deactivated"`
+	UserSuspended   QueueConfig `mapstructure:"user-suspended"`
+	UserRoleChanged QueueConfig `mapstructure:"user-role-changed"`
+	UserDeleted     QueueConfig `mapstructure:"user-deleted"`
+}
+
+// Consumed returns the queues this service consumes, each gets a dead-letter queue
+func (q *QueuesConfig) Consumed() []QueueConfig {
+	return []QueueConfig{q.UserActivity}
 }
 
 type QueueConfig struct {
-	Name       string `mapstructure:"name"`
-	RoutingKey string `mapstructure:"routing-key"`
-	Consumer   string `mapstructure:"consumer"`
+	Name            string `mapstructure:"name"`
+	RoutingKey      string `mapstructure:"routing-key"`
+	Consumer        string `mapstructure:"consumer"`
+	DeadLetterQueue string `mapstructure:"dead-letter-queue"`
+
+	DeadLetterConsumer string `mapstructure:"dead-letter-consumer"`
+	MaxRetries         int    `mapstructure:"max-retries"`
 }
 
 type SecuritySettings struct {
-	JwtKey string `mapstructure:"jwt-key"`
+	JwtKey string       `mapstructure:"jwt-key"`
+	JWT    JWTConfig    `mapstructure:"jwt"`
+	RBAC   RBACConfig   `mapstructure:"rbac"`
+	StepUp StepUpConfig `mapstructure:"step-up"`
+}
+
+type StepUpConfig struct {
+	MaxAgeSeconds int `mapstructure:"max-age-seconds"`
+}
+
+type JWTConfig struct {
+	Algorithm        string `mapstructure:"algorithm"`
+	PublicKeyFile    string `mapstructure:"public-key-file"`
+	JWKSURL          string `mapstructure:"jwks-url"`
+	JWKSCacheMinutes int    `mapstructure:"jwks-cache-minutes"`
+	JWKSTimeout      int    `mapstructure:"jwks-timeout"`
+	Issuer           string `mapstructure:"issuer"`
+	Audience         string `mapstructure:"audience"`
+}
+
+type RBACConfig struct {
+	Roles map[string][]string `mapstructure:"roles"`
 }
 
 type CacheConfig struct {
@@ -106,6 +171,7 @@ type CacheConfig struct {
 
 type UserStatsCache struct {
 	Key               string `mapstructure:"key"`
+	TimeSeriesKey     string `mapstructure:"timeseries-key"`
 	ExpirationMinutes int    `mapstructure:"expiration-minutes"`
 }
 
@@ -123,6 +189,49 @@ type AuthServiceConfig struct {
 	Timeout int    `mapstructure:"timeout"`
 }
 
+type JobsConfig struct {
+	SuspensionExpiry JobConfig            `mapstructure:"suspension-expiry"`
+	BulkStatus       BulkStatusJobConfig  `mapstructure:"bulk-status"`
+	Erasure          ErasureJobConfig     `mapstructure:"erasure"`
+	Export           ExportJobConfig      `mapstructure:"export"`
+	OutboxRelay      OutboxRelayJobConfig `mapstructure:"outbox-relay"`
+}
+
+type JobConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalSeconds int  `mapstructure:"interval-seconds"`
+	BatchSize       int  `mapstructure:"batch-size"`
+}
+
+type BulkStatusJobConfig struct {
+	JobConfig      `mapstructure:",squash"`
+	MaxUsers       int `mapstructure:"max-users"`
+	AsyncThreshold int `mapstructure:"async-threshold"`
+	LeaseMinutes   int `mapstructure:"lease-minutes"`
+}
+
+type OutboxRelayJobConfig struct {
+	JobConfig         `mapstructure:",squash"`
+	MaxAttempts       int `mapstructure:"max-attempts"`
+	RetryDelaySeconds int `mapstructure:"retry-delay-seconds"`
+	LeaseSeconds      int `mapstructure:"lease-seconds"`
+	RetentionHours    int `mapstructure:"retention-hours"`
+}
+
+type ExportJobConfig struct {
+	JobConfig    `mapstructure:",squash"`
+	LeaseMinutes int `mapstructure:"lease-minutes"`
+	MaxAttempts  int `mapstructure:"max-attempts"`
+}
+
+type ErasureJobConfig struct {
+	JobConfig         `mapstructure:",squash"`
+	GracePeriodHours  int `mapstructure:"grace-period-hours"`
+	MaxAttempts       int `mapstructure:"max-attempts"`
+	RetryDelaySeconds int `mapstructure:"retry-delay-seconds"`
+	LeaseMinutes      int `mapstructure:"lease-minutes"`
+}
+
 func Load() *Configuration {
 	cfg := read()
 	logrus.Info("Configuration loaded")
@@ -154,6 +263,10 @@ func Load() *Configuration {
 		cfg.Security.JwtKey = jwtKey
 	}
 
+	if jwksURL := os.Getenv("JWKS_URL"); jwksURL != "" {
+		cfg.Security.JWT.JWKSURL = jwksURL
+	}
+
 	if authServiceURL := os.Getenv("AUTH_SERVICE_URL"); authServiceURL != "" {
 		cfg.ExternalServices.AuthService.URL = authServiceURL
 	}
@@ -170,13 +283,25 @@ func read() *Configuration {
 
 	err := viper.ReadInConfig()
 	if err != nil {
-		logrus.Panic("Error reading config file, %s", err)
+		logrus.Panicf("Error reading config file, %s", err)
 	}
 
 	err = viper.Unmarshal(&config)
 	if err != nil {
-		logrus.Panic("Error unmarshalling config file, %s", err)
+		logrus.Panicf("Error unmarshalling config file, %s", err)
 	}
 
 	return &config
 }
+
+type ApprovalsConfig struct {
+	Enabled         bool     `mapstructure:"enabled"`
+	Actions         []string `mapstructure:"actions"`
+	TargetRoles     []string `mapstructure:"target-roles"`
+	ExpirationHours int      `mapstructure:"expiration-hours"`
+}
+
+type ExportsConfig struct {
+	Directory       string `mapstructure:"directory"`
+	ExpirationHours int    `mapstructure:"expiration-hours"`
+}
@@ -0,0 +1,113 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// Consumer moves messages off the dead-letter queues of every consumed queue into storage,
+// where admins can inspect, replay or purge them
+type Consumer struct {
+	consumers []*clients.QueueConsumer
+	service   Service
+	timeout   time.Duration
+}
+
+func NewConsumer(rabbitMQ *clients.RabbitMQ, service Service, cfg *config.Configuration) *Consumer {
+	c := &Consumer{
+		service: service,
+		timeout: time.Duration(cfg.App.Timeout) * time.Second,
+	}
+	for _, queue := range cfg.Messaging.Queues.Consumed() {
+		c.consumers = append(c.consumers, clients.NewDeadLetterConsumer(rabbitMQ, queue, c.handler(queue)))
+	}
+	return c
+}
+
+// Start consumes the dead-letter queues in the background until Stop is called
+func (c *Consumer) Start() {
+	for _, consumer := range c.consumers {
+		consumer.Start()
+	}
+}
+
+// Stop cancels the consumers and waits for in-flight messages to be stored
+func (c *Consumer) Stop() {
+	for _, consumer := range c.consumers {
+		consumer.Stop()
+	}
+}
+
+func (c *Consumer) handler(queue config.QueueConfig) clients.DeliveryHandler {
+	return func(delivery amqp.Delivery) error {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		defer cancel()
+
+		return c.service.Store(ctx, toMessage(queue, delivery))
+	}
+}
+
+// toMessage reads the failure headers set by the consumer; messages rejected straight to
+// the dead-letter exchange only carry the broker's x-death header
+func toMessage(queue config.QueueConfig, delivery amqp.Delivery) *Message {
+	message := &Message{
+		Queue:           queue.Name,
+		DeadLetterQueue: queue.DeadLetterQueue,
+		RoutingKey:      stringHeader(delivery.Headers, clients.HeaderOriginalRoutingKey),
+		MessageID:       delivery.MessageId,
+		ContentType:     delivery.ContentType,
+		Headers:         make(map[string]string),
+		Body:            delivery.Body,
+		FailureReason:   stringHeader(delivery.Headers, clients.HeaderFailureReason),
+		RetryCount:      clients.RetryCount(delivery.Headers),
+		FailedAt:        time.Now(),
+	}
+
+	// Consumed payloads identify the user by user_id; it lets erasure find the dead letters
+	var subject struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(delivery.Body, &subject); err == nil {
+		message.UserID = subject.UserID
+	}
+
+	if failedAt, err := time.Parse(time.RFC3339, stringHeader(delivery.Headers, clients.HeaderFailedAt)); err == nil {
+		message.FailedAt = failedAt
+	}
+	if message.FailureReason == "" {
+		message.FailureReason = "rejected by the broker: " + deathReason(delivery.Headers)
+	}
+
+	for key, value := range delivery.Headers {
+		switch key {
+		case clients.HeaderRetryCount, clients.HeaderFailureReason, clients.HeaderFailedAt,
+			clients.HeaderOriginalQueue, clients.HeaderOriginalRoutingKey, "x-death":
+			continue
+		}
+		message.Headers[key] = fmt.Sprint(value)
+	}
+
+	return message
+}
+
+func stringHeader(headers amqp.Table, key string) string {
+	value, _ := headers[key].(string)
+	return value
+}
+
+func deathReason(headers amqp.Table) string {
+	if deaths, ok := headers["x-death"].([]interface{}); ok && len(deaths) > 0 {
+		if death, ok := deaths[0].(amqp.Table); ok {
+			if reason, ok := death["reason"].(string); ok {
+				return reason
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,172 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	GetDeadLetters(c *gin.Context)
+	GetDeadLetter(c *gin.Context)
+	ReplayDeadLetter(c *gin.Context)
+	DeleteDeadLetter(c *gin.Context)
+	PurgeDeadLetters(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) GetDeadLetters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req := &ListRequest{
+		Queue:  c.Query("queue"),
+		Status: c.DefaultQuery("status", StatusPending),
+		Limit:  limit,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"queue": req.Queue, "status": req.Status, "limit": req.Limit,
+	}).Info("GetDeadLetters request received")
+
+	messages, err := h.service.List(ctx, req)
+	if err != nil {
+		h.handleError(c, "", err, "Failed to retrieve dead letters")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    messages,
+		"message": "Dead letters retrieved successfully",
+	})
+}
+
+func (h *handler) GetDeadLetter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	messageID := c.Param("id")
+	logrus.WithField("dead_letter_id", messageID).Info("GetDeadLetter request received")
+
+	message, err := h.service.GetByID(ctx, messageID)
+	if err != nil {
+		h.handleError(c, messageID, err, "Failed to retrieve dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    message,
+		"message": "Dead letter retrieved successfully",
+	})
+}
+
+func (h *handler) ReplayDeadLetter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	messageID := c.Param("id")
+	logrus.WithField("dead_letter_id", messageID).Info("ReplayDeadLetter request received")
+
+	message, err := h.service.Replay(ctx, messageID, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, messageID, err, "Failed to replay dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    message,
+		"message": "Dead letter replayed successfully",
+	})
+}
+
+func (h *handler) DeleteDeadLetter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	messageID := c.Param("id")
+	logrus.WithField("dead_letter_id", messageID).Info("DeleteDeadLetter request received")
+
+	if err := h.service.Delete(ctx, messageID, middleware.ActorFromContext(c)); err != nil {
+		h.handleError(c, messageID, err, "Failed to delete dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Dead letter deleted successfully",
+	})
+}
+
+func (h *handler) PurgeDeadLetters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	req := &PurgeRequest{
+		Queue:  c.Query("queue"),
+		Status: c.Query("status"),
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"queue": req.Queue, "status": req.Status,
+	}).Info("PurgeDeadLetters request received")
+
+	deleted, err := h.service.Purge(ctx, req, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, req.Queue, err, "Failed to purge dead letters")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"deleted": deleted},
+		"message": "Dead letters purged successfully",
+	})
+}
+
+func (h *handler) handleError(c *gin.Context, id string, err error, message string) {
+	logrus.WithError(err).WithField("id", id).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Please provide a valid ID and status")
+	case errors.Is(err, models.ErrUnknownQueue):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Please provide a queue consumed by this service")
+	case errors.Is(err, models.ErrDeadLetterNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Dead letter not found", "No dead letter found with the provided ID")
+	case errors.Is(err, models.ErrDeadLetterReplayed):
+		h.sendErrorResponse(c, http.StatusConflict, "Dead letter already replayed", err.Error())
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
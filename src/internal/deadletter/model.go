@@ -0,0 +1,56 @@
+package deadletter
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Dead letter status constants
+const (
+	StatusPending  = "pending"
+	StatusReplayed = "replayed"
+)
+
+// Message is a consumed message that failed processing and was moved off its dead-letter queue
+type Message struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Queue           string             `json:"queue" bson:"queue"`
+	UserID          string             `json:"userId,omitempty" bson:"user_id,omitempty"`
+	DeadLetterQueue string             `json:"deadLetterQueue" bson:"dead_letter_queue"`
+	RoutingKey      string             `json:"routingKey,omitempty" bson:"routing_key,omitempty"`
+	MessageID       string             `json:"messageId,omitempty" bson:"message_id,omitempty"`
+	ContentType     string             `json:"contentType,omitempty" bson:"content_type,omitempty"`
+	Headers         map[string]string  `json:"headers,omitempty" bson:"headers,omitempty"`
+	Body            []byte             `json:"-" bson:"body"`
+	Payload         string             `json:"payload,omitempty" bson:"-"`
+	FailureReason   string             `json:"failureReason" bson:"failure_reason"`
+	RetryCount      int                `json:"retryCount" bson:"retry_count"`
+	Status          string             `json:"status" bson:"status"`
+	FailedAt        time.Time          `json:"failedAt" bson:"failed_at"`
+	ReceivedAt      time.Time          `json:"receivedAt" bson:"received_at"`
+	ReplayedAt      *time.Time         `json:"replayedAt,omitempty" bson:"replayed_at,omitempty"`
+	ReplayedBy      *models.Actor      `json:"replayedBy,omitempty" bson:"replayed_by,omitempty"`
+}
+
+// ListRequest represents filters for listing dead letters
+type ListRequest struct {
+	Queue  string
+	Status string
+	Limit  int
+}
+
+// PurgeRequest selects the dead letters to delete, the queue is required
+type PurgeRequest struct {
+	Queue  string
+	Status string
+}
+
+func isValidStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusReplayed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, message *Message) error
+	List(ctx context.Context, queue, status string, limit int) ([]*Message, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Message, error)
+	ClaimReplay(ctx context.Context, id primitive.ObjectID, actor *models.Actor, at time.Time) (*Message, error)
+	ReleaseReplay(ctx context.Context, id primitive.ObjectID) error
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, queue, status string) (int64, error)
+	DeleteByUser(ctx context.Context, userID string) (int64, error)
+}
+
+type deadLetterRepository struct {
+	Collection mongo.Collection
+}
+
+func NewDeadLetterRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &deadLetterRepository{
+		Collection: collection,
+	}
+}
+
+func (r *deadLetterRepository) Insert(ctx context.Context, message *Message) error {
+	result, err := r.Collection.InsertOne(ctx, message)
+	if err != nil {
+		logrus.WithError(err).WithField("queue", message.Queue).Error("Failed to insert dead letter")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		message.ID = id
+	}
+	return nil
+}
+
+// List returns dead letters newest first, the body is left out
+func (r *deadLetterRepository) List(ctx context.Context, queue, status string, limit int) ([]*Message, error) {
+	filter := bson.M{}
+	if queue != "" {
+		filter["queue"] = queue
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"received_at": -1}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"body": 0})
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to list dead letters")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := make([]*Message, 0)
+	if err := cursor.All(ctx, &messages); err != nil {
+		logrus.WithError(err).Error("Failed to decode dead letters")
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *deadLetterRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*Message, error) {
+	var message Message
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&message); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrDeadLetterNotFound
+		}
+		logrus.WithError(err).WithField("dead_letter_id", id.Hex()).Error("Failed to get dead letter")
+		return nil, err
+	}
+	return &message, nil
+}
+
+// ClaimReplay atomically marks a pending dead letter as replayed, so it is published once
+func (r *deadLetterRepository) ClaimReplay(ctx context.Context, id primitive.ObjectID, actor *models.Actor, at time.Time) (*Message, error) {
+	filter := bson.M{"_id": id, "status": StatusPending}
+	update := bson.M{"$set": bson.M{
+		"status":      StatusReplayed,
+		"replayed_at": at,
+		"replayed_by": actor,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var message Message
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrDeadLetterReplayed
+		}
+		logrus.WithError(err).WithField("dead_letter_id", id.Hex()).Error("Failed to claim dead letter for replay")
+		return nil, err
+	}
+	return &message, nil
+}
+
+// ReleaseReplay puts a claimed dead letter back to pending after its replay failed
+func (r *deadLetterRepository) ReleaseReplay(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set":   bson.M{"status": StatusPending},
+		"$unset": bson.M{"replayed_at": "", "replayed_by": ""},
+	}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("dead_letter_id", id.Hex()).Error("Failed to release dead letter")
+		return err
+	}
+	return nil
+}
+
+func (r *deadLetterRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		logrus.WithError(err).WithField("dead_letter_id", id.Hex()).Error("Failed to delete dead letter")
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+func (r *deadLetterRepository) Delete(ctx context.Context, queue, status string) (int64, error) {
+	filter := bson.M{"queue": queue}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	result, err := r.Collection.DeleteMany(ctx, filter)
+	if err != nil {
+		logrus.WithError(err).WithField("queue", queue).Error("Failed to purge dead letters")
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *deadLetterRepository) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	result, err := r.Collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to delete user dead letters")
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
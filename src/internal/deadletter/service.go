@@ -0,0 +1,219 @@
+package deadletter
+
+import (
+	"context"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Service interface {
+	Store(ctx context.Context, message *Message) error
+	List(ctx context.Context, req *ListRequest) ([]*Message, error)
+	GetByID(ctx context.Context, id string) (*Message, error)
+	Replay(ctx context.Context, id string, actor *models.Actor) (*Message, error)
+	Delete(ctx context.Context, id string, actor *models.Actor) error
+	Purge(ctx context.Context, req *PurgeRequest, actor *models.Actor) (int64, error)
+	DeleteUserMessages(ctx context.Context, userID string) (int64, error)
+}
+
+type deadLetterService struct {
+	deadLetterRepository Repository
+	auditService         audit.Service
+	rabbitMQ             *clients.RabbitMQ
+	cfg                  *config.Configuration
+}
+
+func NewDeadLetterService(deadLetterRepository Repository,
+	auditService audit.Service,
+	rabbitMQ *clients.RabbitMQ,
+	cfg *config.Configuration) Service {
+	return &deadLetterService{
+		deadLetterRepository: deadLetterRepository,
+		auditService:         auditService,
+		rabbitMQ:             rabbitMQ,
+		cfg:                  cfg,
+	}
+}
+
+// Store keeps a message taken off a dead-letter queue until an admin replays or purges it
+func (s *deadLetterService) Store(ctx context.Context, message *Message) error {
+	message.Status = StatusPending
+	message.ReceivedAt = time.Now()
+	if err := s.deadLetterRepository.Insert(ctx, message); err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"dead_letter_id": message.ID.Hex(), "queue": message.Queue,
+		"failure_reason": message.FailureReason, "retries": message.RetryCount,
+	}).Warn("Dead letter stored")
+
+	return nil
+}
+
+func (s *deadLetterService) List(ctx context.Context, req *ListRequest) ([]*Message, error) {
+	if req.Queue != "" && !s.isConsumedQueue(req.Queue) {
+		return nil, models.ErrUnknownQueue
+	}
+	if req.Status != "" && !isValidStatus(req.Status) {
+		return nil, models.ErrInvalidParams
+	}
+	if req.Limit <= 0 {
+		req.Limit = s.cfg.Search.MinQueryLimit
+	}
+	if req.Limit > s.cfg.Search.MaxQueryLimit {
+		req.Limit = s.cfg.Search.MaxQueryLimit
+	}
+
+	return s.deadLetterRepository.List(ctx, req.Queue, req.Status, req.Limit)
+}
+
+// GetByID returns the dead letter together with its payload
+func (s *deadLetterService) GetByID(ctx context.Context, id string) (*Message, error) {
+	messageID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	message, err := s.deadLetterRepository.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	message.Payload = string(message.Body)
+	return message, nil
+}
+
+// Replay publishes the dead letter back to its original queue with a fresh retry count
+func (s *deadLetterService) Replay(ctx context.Context, id string, actor *models.Actor) (*Message, error) {
+	message, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Status != StatusPending {
+		return nil, models.ErrDeadLetterReplayed
+	}
+
+	claimed, err := s.deadLetterRepository.ClaimReplay(ctx, message.ID, actor, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.rabbitMQ.PublishToQueue(claimed.Queue, toPublishing(claimed)); err != nil {
+		if releaseErr := s.deadLetterRepository.ReleaseReplay(ctx, claimed.ID); releaseErr != nil {
+			logrus.WithError(releaseErr).WithField("dead_letter_id", id).Error("Dead letter left replayed after failed publish")
+		}
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:    *actor,
+		Action:   audit.ActionReplayDeadLetter,
+		Metadata: map[string]string{"dead_letter_id": id, "queue": claimed.Queue},
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"dead_letter_id": id, "queue": claimed.Queue, "actor_id": actor.ID,
+	}).Info("Dead letter replayed")
+
+	claimed.Payload = message.Payload
+	return claimed, nil
+}
+
+func (s *deadLetterService) Delete(ctx context.Context, id string, actor *models.Actor) error {
+	messageID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.ErrInvalidParams
+	}
+
+	if err := s.deadLetterRepository.DeleteByID(ctx, messageID); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:    *actor,
+		Action:   audit.ActionPurgeDeadLetters,
+		Metadata: map[string]string{"dead_letter_id": id, "deleted": "1"},
+	})
+
+	logrus.WithFields(logrus.Fields{"dead_letter_id": id, "actor_id": actor.ID}).Info("Dead letter deleted")
+	return nil
+}
+
+// Purge deletes the dead letters of a queue, optionally only those with the given status
+func (s *deadLetterService) Purge(ctx context.Context, req *PurgeRequest, actor *models.Actor) (int64, error) {
+	if !s.isConsumedQueue(req.Queue) {
+		return 0, models.ErrUnknownQueue
+	}
+	if req.Status != "" && !isValidStatus(req.Status) {
+		return 0, models.ErrInvalidParams
+	}
+
+	deleted, err := s.deadLetterRepository.Delete(ctx, req.Queue, req.Status)
+	if err != nil {
+		return 0, err
+	}
+
+	metadata := map[string]string{"queue": req.Queue, "deleted": strconv.FormatInt(deleted, 10)}
+	if req.Status != "" {
+		metadata["status"] = req.Status
+	}
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:    *actor,
+		Action:   audit.ActionPurgeDeadLetters,
+		Metadata: metadata,
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"queue": req.Queue, "status": req.Status, "deleted": deleted, "actor_id": actor.ID,
+	}).Info("Dead letters purged")
+
+	return deleted, nil
+}
+
+// DeleteUserMessages removes dead letters carrying data of an erased user
+func (s *deadLetterService) DeleteUserMessages(ctx context.Context, userID string) (int64, error) {
+	return s.deadLetterRepository.DeleteByUser(ctx, userID)
+}
+
+func (s *deadLetterService) isConsumedQueue(name string) bool {
+	for _, queue := range s.cfg.Messaging.Queues.Consumed() {
+		if queue.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAudit writes an audit entry; a failure here is logged rather than returned to the caller
+func (s *deadLetterService) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":   entry.Action,
+			"metadata": entry.Metadata,
+		}).Error("Failed to record audit entry")
+	}
+}
+
+func toPublishing(message *Message) amqp.Publishing {
+	headers := make(amqp.Table, len(message.Headers))
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  message.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    message.MessageID,
+		Timestamp:    time.Now(),
+		Body:         message.Body,
+	}
+}
@@ -2,23 +2,58 @@ package dependency
 
 import (
 	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/activity"
+	"handyhub-admin-svc/src/internal/apikey"
+	"handyhub-admin-svc/src/internal/approval"
+	"handyhub-admin-svc/src/internal/audit"
 	"handyhub-admin-svc/src/internal/cache"
 	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/deadletter"
+	"handyhub-admin-svc/src/internal/erasure"
+	"handyhub-admin-svc/src/internal/export"
+	"handyhub-admin-svc/src/internal/outbox"
+	"handyhub-admin-svc/src/internal/rbac"
+	"handyhub-admin-svc/src/internal/session"
 	"handyhub-admin-svc/src/internal/user"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Job is a background worker started and stopped together with the server
+type Job interface {
+	Start()
+	Stop()
+}
+
 type Manager struct {
-	Router       *gin.Engine
-	Config       *config.Configuration
-	Mongodb      *clients.MongoDB
-	Redis        *clients.RedisClient
-	RabbitMQ     *clients.RabbitMQ
-	UserService  user.Service
-	UserHandler  user.Handler
-	CacheService cache.Service
-	AuthClient   *clients.AuthClient
+	Router            *gin.Engine
+	Config            *config.Configuration
+	Mongodb           *clients.MongoDB
+	Redis             *clients.RedisClient
+	RabbitMQ          *clients.RabbitMQ
+	UserService       user.Service
+	UserHandler       user.Handler
+	SessionService    session.Service
+	SessionHandler    session.Handler
+	AuditService      audit.Service
+	AuditHandler      audit.Handler
+	CacheService      cache.Service
+	AuthClient        *clients.AuthClient
+	ActivityPublisher *clients.ActivityPublisher
+	Authorizer        *rbac.Authorizer
+	ActivityService   activity.Service
+	ActivityHandler   activity.Handler
+	APIKeyService     apikey.Service
+	APIKeyHandler     apikey.Handler
+	ApprovalService   approval.Service
+	ApprovalHandler   approval.Handler
+	ErasureService    erasure.Service
+	ErasureHandler    erasure.Handler
+	ExportService     export.Service
+	ExportHandler     export.Handler
+	DeadLetterService deadletter.Service
+	DeadLetterHandler deadletter.Handler
+	Jobs              []Job
 }
 
 func NewDependencyManager(router *gin.Engine,
@@ -28,19 +63,86 @@ func NewDependencyManager(router *gin.Engine,
 	cfg *config.Configuration) *Manager {
 	cacheService := cache.NewCacheService(redisClient.Client, cfg)
 	userRepo := user.NewUserRepository(mongodb, cfg.Database.Collections.Users)
-	userService := user.NewUserService(userRepo, cfg)
+	sessionRepo := session.NewSessionRepository(mongodb, cfg.Database.Collections.Sessions)
+	auditRepo := audit.NewAuditRepository(mongodb, cfg.Database.Collections.Audit)
+	auditService := audit.NewAuditService(auditRepo, cfg)
+	auditHandler := audit.NewHandler(cfg, auditService)
+	authClient := clients.NewAuthClient(cfg, rabbitMQ)
+	activityPublisher := clients.NewActivityPublisher(rabbitMQ, &cfg.Messaging)
+	sessionService := session.NewSessionService(sessionRepo, cacheService, auditService, authClient, cfg)
+	sessionHandler := session.NewHandler(cfg, sessionService)
+	authorizer := rbac.NewAuthorizer(cfg)
+	approvalRepo := approval.NewApprovalRepository(mongodb, cfg.Database.Collections.Approvals)
+	approvalService := approval.NewApprovalService(approvalRepo, auditService, authorizer, cfg)
+	approvalHandler := approval.NewHandler(cfg, approvalService)
+	outboxRepo := outbox.NewOutboxRepository(mongodb, cfg.Database.Collections.Outbox)
+	outboxLeaseRepo := outbox.NewLeaseRepository(mongodb, cfg.Database.Collections.OutboxLeases)
+	outboxService := outbox.NewOutboxService(outboxRepo, outboxLeaseRepo, mongodb, rabbitMQ, cfg)
+	outboxRelayJob := outbox.NewRelayJob(outboxService, cfg)
+	bulkJobRepo := user.NewBulkJobRepository(mongodb, cfg.Database.Collections.BulkJobs)
+	userService := user.NewUserService(userRepo, bulkJobRepo, sessionService, auditService, cacheService,
+		approvalService, outboxService, cfg)
+	approvalService.RegisterExecutor(audit.ActionSuspendUser, rbac.PermUsersSuspend,
+		approval.ExecutorFunc(userService.ExecuteApproved))
+	approvalService.RegisterExecutor(audit.ActionDeactivateUser, rbac.PermUsersDeactivate,
+		approval.ExecutorFunc(userService.ExecuteApproved))
+	approvalService.RegisterExecutor(audit.ActionDeleteUser, rbac.PermUsersDelete,
+		approval.ExecutorFunc(userService.ExecuteApproved))
+	approvalService.RegisterExecutor(audit.ActionBulkStatus, rbac.PermUsersBulkUpdate,
+		approval.ExecutorFunc(userService.ExecuteApproved))
 	userHandler := user.NewHandler(cfg, userService, cacheService)
-	authClient := clients.NewAuthClient(cfg, rabbitMQ.Channel)
+	suspensionExpiryJob := user.NewSuspensionExpiryJob(userService, cfg)
+	bulkStatusJob := user.NewBulkStatusJob(userService, cfg)
+	activityRepo := activity.NewActivityRepository(mongodb, cfg.Database.Collections.Activity)
+	activityService := activity.NewActivityService(activityRepo, cfg)
+	activityHandler := activity.NewHandler(cfg, activityService)
+	activityConsumer := activity.NewConsumer(rabbitMQ, activityService, cfg)
+	apiKeyRepo := apikey.NewAPIKeyRepository(mongodb, cfg.Database.Collections.APIKeys)
+	apiKeyService := apikey.NewAPIKeyService(apiKeyRepo, auditService, authorizer, cfg)
+	apiKeyHandler := apikey.NewHandler(cfg, apiKeyService)
+	exportRepo := export.NewExportRepository(mongodb, cfg.Database.Collections.Exports)
+	exportService := export.NewExportService(exportRepo, userService, sessionService, activityService, auditService, cfg)
+	exportHandler := export.NewHandler(cfg, exportService)
+	exportJob := export.NewJob(exportService, cfg)
+	deadLetterRepo := deadletter.NewDeadLetterRepository(mongodb, cfg.Database.Collections.DeadLetters)
+	deadLetterService := deadletter.NewDeadLetterService(deadLetterRepo, auditService, rabbitMQ, cfg)
+	deadLetterHandler := deadletter.NewHandler(cfg, deadLetterService)
+	deadLetterConsumer := deadletter.NewConsumer(rabbitMQ, deadLetterService, cfg)
+	erasureRepo := erasure.NewErasureRepository(mongodb, cfg.Database.Collections.ErasureRequests)
+	certificateRepo := erasure.NewCertificateRepository(mongodb, cfg.Database.Collections.ErasureCertificates)
+	erasureService := erasure.NewErasureService(erasureRepo, certificateRepo, userService, activityService, auditService,
+		approvalService, sessionService, exportService, outboxService, deadLetterService, cfg)
+	erasureHandler := erasure.NewHandler(cfg, erasureService)
+	erasureJob := erasure.NewJob(erasureService, cfg)
 
 	return &Manager{
-		Router:       router,
-		Config:       cfg,
-		Mongodb:      mongodb,
-		Redis:        redisClient,
-		RabbitMQ:     rabbitMQ,
-		UserService:  userService,
-		UserHandler:  userHandler,
-		CacheService: cacheService,
-		AuthClient:   authClient,
+		Router:            router,
+		Config:            cfg,
+		Mongodb:           mongodb,
+		Redis:             redisClient,
+		RabbitMQ:          rabbitMQ,
+		UserService:       userService,
+		UserHandler:       userHandler,
+		SessionService:    sessionService,
+		SessionHandler:    sessionHandler,
+		AuditService:      auditService,
+		AuditHandler:      auditHandler,
+		CacheService:      cacheService,
+		AuthClient:        authClient,
+		ActivityPublisher: activityPublisher,
+		Authorizer:        authorizer,
+		ActivityService:   activityService,
+		ActivityHandler:   activityHandler,
+		APIKeyService:     apiKeyService,
+		APIKeyHandler:     apiKeyHandler,
+		ApprovalService:   approvalService,
+		ApprovalHandler:   approvalHandler,
+		ErasureService:    erasureService,
+		ErasureHandler:    erasureHandler,
+		ExportService:     exportService,
+		ExportHandler:     exportHandler,
+		DeadLetterService: deadLetterService,
+		DeadLetterHandler: deadLetterHandler,
+		Jobs:              []Job{suspensionExpiryJob, bulkStatusJob, erasureJob, exportJob, outboxRelayJob, activityConsumer, deadLetterConsumer},
 	}
 }
@@ -0,0 +1,71 @@
+package erasure
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CertificateRepository interface {
+	Insert(ctx context.Context, certificate *Certificate) error
+	GetByRequestID(ctx context.Context, requestID string) (*Certificate, error)
+	FindByUser(ctx context.Context, userID string) (*Certificate, error)
+}
+
+type certificateRepository struct {
+	Collection mongo.Collection
+}
+
+func NewCertificateRepository(mongoClient *clients.MongoDB, collectionName string) CertificateRepository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &certificateRepository{
+		Collection: collection,
+	}
+}
+
+// Insert stores one certificate per request; when a retried erasure already issued
+// one, the stored certificate is loaded into certificate instead
+func (r *certificateRepository) Insert(ctx context.Context, certificate *Certificate) error {
+	filter := bson.M{"request_id": certificate.RequestID}
+	update := bson.M{"$setOnInsert": certificate}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(certificate); err != nil {
+		logrus.WithError(err).WithField("request_id", certificate.RequestID).Error("Failed to insert erasure certificate")
+		return err
+	}
+	return nil
+}
+
+func (r *certificateRepository) GetByRequestID(ctx context.Context, requestID string) (*Certificate, error) {
+	var certificate Certificate
+	if err := r.Collection.FindOne(ctx, bson.M{"request_id": requestID}).Decode(&certificate); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrCertificateNotFound
+		}
+		logrus.WithError(err).WithField("request_id", requestID).Error("Failed to get erasure certificate")
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+// FindByUser returns the certificate of a completed erasure of the user, nil if there is none
+func (r *certificateRepository) FindByUser(ctx context.Context, userID string) (*Certificate, error) {
+	var certificate Certificate
+	if err := r.Collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&certificate); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to find erasure certificate")
+		return nil, err
+	}
+	return &certificate, nil
+}
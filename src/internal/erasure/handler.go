@@ -0,0 +1,160 @@
+package erasure
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	RequestErasure(c *gin.Context)
+	GetErasureRequest(c *gin.Context)
+	CancelErasure(c *gin.Context)
+	GetCertificate(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) RequestErasure(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Warn("Invalid erasure request body")
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", "An erasure reason is required")
+		return
+	}
+
+	userID := c.Param("id")
+	logrus.WithField("user_id", userID).Info("RequestErasure request received")
+
+	request, err := h.service.RequestErasure(ctx, userID, &req, middleware.ActorFromContext(c))
+	if err != nil {
+		if errors.Is(err, models.ErrErasureAlreadyRequested) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Erasure already requested",
+				"success": false,
+				"data":    request,
+				"message": err.Error(),
+			})
+			return
+		}
+		h.handleError(c, userID, err, "Failed to request user erasure")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    request,
+		"message": "User erasure scheduled successfully",
+	})
+}
+
+func (h *handler) GetErasureRequest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	requestID := c.Param("id")
+	logrus.WithField("erasure_id", requestID).Info("GetErasureRequest request received")
+
+	request, err := h.service.GetByID(ctx, requestID)
+	if err != nil {
+		h.handleError(c, requestID, err, "Failed to retrieve erasure request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    request,
+		"message": "Erasure request retrieved successfully",
+	})
+}
+
+func (h *handler) CancelErasure(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	requestID := c.Param("id")
+	logrus.WithField("erasure_id", requestID).Info("CancelErasure request received")
+
+	request, err := h.service.Cancel(ctx, requestID, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, requestID, err, "Failed to cancel erasure request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    request,
+		"message": "Erasure request cancelled successfully",
+	})
+}
+
+func (h *handler) GetCertificate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	requestID := c.Param("id")
+	logrus.WithField("erasure_id", requestID).Info("GetErasureCertificate request received")
+
+	certificate, err := h.service.GetCertificate(ctx, requestID)
+	if err != nil {
+		h.handleError(c, requestID, err, "Failed to retrieve erasure certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    certificate,
+		"message": "Erasure certificate retrieved successfully",
+	})
+}
+
+func (h *handler) handleError(c *gin.Context, id string, err error, message string) {
+	logrus.WithError(err).WithField("id", id).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Please provide a valid ID")
+	case errors.Is(err, models.ErrErasureReasonRequired):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", err.Error())
+	case errors.Is(err, models.ErrUserNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "User not found", "No user found with the provided ID")
+	case errors.Is(err, models.ErrErasureNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Erasure request not found", "No erasure request found with the provided ID")
+	case errors.Is(err, models.ErrCertificateNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Erasure certificate not found", "The erasure has not been completed")
+	case errors.Is(err, models.ErrSelfDelete), errors.Is(err, models.ErrStaffDelete):
+		h.sendErrorResponse(c, http.StatusForbidden, "Erasure not permitted", err.Error())
+	case errors.Is(err, models.ErrUserErased), errors.Is(err, models.ErrErasureNotCancellable):
+		h.sendErrorResponse(c, http.StatusConflict, "Erasure request cannot be changed", err.Error())
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
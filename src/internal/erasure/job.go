@@ -0,0 +1,87 @@
+package erasure
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Job executes erasure requests once their grace period is over
+type Job struct {
+	service  Service
+	cfg      *config.ErasureJobConfig
+	timeout  time.Duration
+	stop     chan struct{}
+	finished chan struct{}
+}
+
+func NewJob(service Service, cfg *config.Configuration) *Job {
+	return &Job{
+		service:  service,
+		cfg:      &cfg.Jobs.Erasure,
+		timeout:  time.Duration(cfg.App.Timeout) * time.Second,
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+// Start runs the job in the background until Stop is called
+func (j *Job) Start() {
+	if !j.cfg.Enabled {
+		logrus.Info("Erasure job is disabled")
+		close(j.finished)
+		return
+	}
+
+	interval := time.Duration(j.cfg.IntervalSeconds) * time.Second
+	logrus.WithFields(logrus.Fields{
+		"interval": interval, "grace_period_hours": j.cfg.GracePeriodHours,
+	}).Info("Starting erasure job")
+
+	go func() {
+		defer close(j.finished)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				j.run()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop signals the job to exit and waits for the running erasure to finish
+func (j *Job) Stop() {
+	close(j.stop)
+	<-j.finished
+	logrus.Info("Erasure job stopped")
+}
+
+// run processes at most BatchSize due requests per tick; each erasure gets its own
+// timeout so a shutdown never interrupts one halfway
+func (j *Job) run() {
+	for i := 0; i < j.cfg.BatchSize; i++ {
+		found, err := j.runNext()
+		if err != nil {
+			logrus.WithError(err).Error("Erasure job failed")
+			return
+		}
+		if !found {
+			return
+		}
+	}
+}
+
+func (j *Job) runNext() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	return j.service.RunNextDue(ctx)
+}
@@ -0,0 +1,80 @@
+package erasure
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Erasure request status constants
+const (
+	StatusScheduled  = "scheduled"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusCancelled  = "cancelled"
+	StatusFailed     = "failed"
+)
+
+// ErasedPlaceholder replaces the email of erased users in audit and approval records
+const ErasedPlaceholder = "[erased]"
+
+// Request is a right-to-erasure request executed after the grace period
+type Request struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID       string             `json:"userId" bson:"user_id"`
+	Reason       string             `json:"reason" bson:"reason"`
+	Status       string             `json:"status" bson:"status"`
+	RequestedBy  models.Actor       `json:"requestedBy" bson:"requested_by"`
+	CancelledBy  *models.Actor      `json:"cancelledBy,omitempty" bson:"cancelled_by,omitempty"`
+	Error        string             `json:"error,omitempty" bson:"error,omitempty"`
+	RequestedAt  time.Time          `json:"requestedAt" bson:"requested_at"`
+	ScheduledFor time.Time          `json:"scheduledFor" bson:"scheduled_for"`
+	Attempts     int                `json:"attempts" bson:"attempts"`
+	Progress     Progress           `json:"progress" bson:"progress"`
+	StartedAt    *time.Time         `json:"startedAt,omitempty" bson:"started_at,omitempty"`
+	FinishedAt   *time.Time         `json:"finishedAt,omitempty" bson:"finished_at,omitempty"`
+}
+
+// Progress counts the records cleaned up so far, summed over all attempts. Audit entries
+// and approval requests keep the user ID, only the email and free-text notes are removed;
+// sessions lose their IP address and user agent.
+type Progress struct {
+	ActivityDeleted        int64 `json:"activityDeleted" bson:"activity_deleted"`
+	AuditEntriesAnonymized int64 `json:"auditEntriesAnonymized" bson:"audit_entries_anonymized"`
+	ApprovalsAnonymized    int64 `json:"approvalsAnonymized" bson:"approvals_anonymized"`
+	BulkJobsAnonymized     int64 `json:"bulkJobsAnonymized" bson:"bulk_jobs_anonymized"`
+	SessionsAnonymized     int64 `json:"sessionsAnonymized" bson:"sessions_anonymized"`
+	ExportsDeleted         int64 `json:"exportsDeleted" bson:"exports_deleted"`
+	OutboxMessagesDeleted  int64 `json:"outboxMessagesDeleted" bson:"outbox_messages_deleted"`
+	DeadLettersDeleted     int64 `json:"deadLettersDeleted" bson:"dead_letters_deleted"`
+}
+
+// Progress field names, as stored in the request document
+const (
+	progressActivityDeleted = "activity_deleted"
+	progressAuditAnonymized = "audit_entries_anonymized"
+	progressApprovals       = "approvals_anonymized"
+	progressBulkJobs        = "bulk_jobs_anonymized"
+	progressSessions        = "sessions_anonymized"
+	progressExports         = "exports_deleted"
+	progressOutbox          = "outbox_messages_deleted"
+	progressDeadLetters     = "dead_letters_deleted"
+)
+
+// Certificate proves that an erasure was executed; it holds no personal data
+type Certificate struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RequestID    string             `json:"requestId" bson:"request_id"`
+	UserID       string             `json:"userId" bson:"user_id"`
+	ErasedFields []string           `json:"erasedFields" bson:"erased_fields"`
+	Progress     `bson:",inline"`
+	RequestedBy  string    `json:"requestedBy" bson:"requested_by"`
+	RequestedAt  time.Time `json:"requestedAt" bson:"requested_at"`
+	ErasedAt     time.Time `json:"erasedAt" bson:"erased_at"`
+}
+
+// CreateRequest represents request body for scheduling an erasure
+type CreateRequest struct {
+	Reason string `json:"reason"`
+}
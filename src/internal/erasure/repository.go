@@ -0,0 +1,171 @@
+package erasure
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, request *Request) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Request, error)
+	FindOpenByUser(ctx context.Context, userID string) (*Request, error)
+	Cancel(ctx context.Context, id primitive.ObjectID, actor *models.Actor, at time.Time) (*Request, error)
+	ClaimDue(ctx context.Context, now, staleBefore time.Time) (*Request, error)
+	AddProgress(ctx context.Context, id primitive.ObjectID, field string, count int64) error
+	Reschedule(ctx context.Context, id primitive.ObjectID, errMessage string, at time.Time) error
+	Finish(ctx context.Context, id primitive.ObjectID, status, errMessage string, at time.Time) error
+}
+
+type erasureRepository struct {
+	Collection mongo.Collection
+}
+
+func NewErasureRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &erasureRepository{
+		Collection: collection,
+	}
+}
+
+func (r *erasureRepository) Insert(ctx context.Context, request *Request) error {
+	result, err := r.Collection.InsertOne(ctx, request)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", request.UserID).Error("Failed to insert erasure request")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		request.ID = id
+	}
+	return nil
+}
+
+func (r *erasureRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*Request, error) {
+	var request Request
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&request); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrErasureNotFound
+		}
+		logrus.WithError(err).WithField("request_id", id.Hex()).Error("Failed to get erasure request")
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindOpenByUser returns the scheduled or running erasure of the user, nil if there is none
+func (r *erasureRepository) FindOpenByUser(ctx context.Context, userID string) (*Request, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []string{StatusScheduled, StatusProcessing}},
+	}
+
+	var request Request
+	if err := r.Collection.FindOne(ctx, filter).Decode(&request); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to find open erasure request")
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Cancel stops a scheduled erasure; requests the job has already started on, including
+// those scheduled again for a retry, are not matched
+func (r *erasureRepository) Cancel(ctx context.Context, id primitive.ObjectID, actor *models.Actor, at time.Time) (*Request, error) {
+	filter := bson.M{
+		"_id":        id,
+		"status":     StatusScheduled,
+		"started_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":       StatusCancelled,
+		"cancelled_by": actor,
+		"finished_at":  at,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var request Request
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&request); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrErasureNotCancellable
+		}
+		logrus.WithError(err).WithField("request_id", id.Hex()).Error("Failed to cancel erasure request")
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ClaimDue atomically marks the oldest due request as processing, so each erasure runs once.
+// Requests left processing since before staleBefore, by a crashed instance, are claimed again.
+func (r *erasureRepository) ClaimDue(ctx context.Context, now, staleBefore time.Time) (*Request, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"status": StatusScheduled, "scheduled_for": bson.M{"$lte": now}},
+		{"status": StatusProcessing, "started_at": bson.M{"$lte": staleBefore}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": StatusProcessing, "started_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"scheduled_for": 1}).
+		SetReturnDocument(options.After)
+
+	var request Request
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&request); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		logrus.WithError(err).Error("Failed to claim erasure request")
+		return nil, err
+	}
+	return &request, nil
+}
+
+// AddProgress adds the records cleaned up by a step to the request counters
+func (r *erasureRepository) AddProgress(ctx context.Context, id primitive.ObjectID, field string, count int64) error {
+	update := bson.M{"$inc": bson.M{"progress." + field: count}}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("request_id", id.Hex()).Error("Failed to save erasure progress")
+		return err
+	}
+	return nil
+}
+
+// Reschedule puts a failed request back to scheduled so the job retries it at the given time
+func (r *erasureRepository) Reschedule(ctx context.Context, id primitive.ObjectID, errMessage string, at time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"status":        StatusScheduled,
+		"error":         errMessage,
+		"scheduled_for": at,
+	}}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("request_id", id.Hex()).Error("Failed to reschedule erasure request")
+		return err
+	}
+	return nil
+}
+
+func (r *erasureRepository) Finish(ctx context.Context, id primitive.ObjectID, status, errMessage string, at time.Time) error {
+	set := bson.M{"status": status, "finished_at": at}
+	if errMessage != "" {
+		set["error"] = errMessage
+	}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, bson.M{"$set": set}); err != nil {
+		logrus.WithError(err).WithField("request_id", id.Hex()).Error("Failed to finish erasure request")
+		return err
+	}
+	return nil
+}
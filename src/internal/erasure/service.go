@@ -0,0 +1,325 @@
+package erasure
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/activity"
+	"handyhub-admin-svc/src/internal/approval"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/deadletter"
+	"handyhub-admin-svc/src/internal/export"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/outbox"
+	"handyhub-admin-svc/src/internal/session"
+	"handyhub-admin-svc/src/internal/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxRetryDelay = 6 * time.Hour
+
+type Service interface {
+	RequestErasure(ctx context.Context, userID string, req *CreateRequest, actor *models.Actor) (*Request, error)
+	GetByID(ctx context.Context, id string) (*Request, error)
+	Cancel(ctx context.Context, id string, actor *models.Actor) (*Request, error)
+	GetCertificate(ctx context.Context, requestID string) (*Certificate, error)
+	RunNextDue(ctx context.Context) (bool, error)
+}
+
+type erasureService struct {
+	erasureRepository     Repository
+	certificateRepository CertificateRepository
+	userService           user.Service
+	activityService       activity.Service
+	auditService          audit.Service
+	approvalService       approval.Service
+	sessionService        session.Service
+	exportService         export.Service
+	outboxService         outbox.Service
+	deadLetterService     deadletter.Service
+	cfg                   *config.Configuration
+}
+
+func NewErasureService(erasureRepository Repository,
+	certificateRepository CertificateRepository,
+	userService user.Service,
+	activityService activity.Service,
+	auditService audit.Service,
+	approvalService approval.Service,
+	sessionService session.Service,
+	exportService export.Service,
+	outboxService outbox.Service,
+	deadLetterService deadletter.Service,
+	cfg *config.Configuration) Service {
+	return &erasureService{
+		erasureRepository:     erasureRepository,
+		certificateRepository: certificateRepository,
+		userService:           userService,
+		activityService:       activityService,
+		auditService:          auditService,
+		approvalService:       approvalService,
+		sessionService:        sessionService,
+		exportService:         exportService,
+		outboxService:         outboxService,
+		deadLetterService:     deadLetterService,
+		cfg:                   cfg,
+	}
+}
+
+// RequestErasure schedules the erasure of the user after the configured grace period
+func (s *erasureService) RequestErasure(ctx context.Context, userID string, req *CreateRequest, actor *models.Actor) (*Request, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, models.ErrErasureReasonRequired
+	}
+
+	target, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ErasedAt != nil {
+		// A user anonymized by an erasure that later failed for good may be erased again
+		certificate, err := s.certificateRepository.FindByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if certificate != nil {
+			return nil, models.ErrUserErased
+		}
+	}
+	if actor.ID == userID {
+		return nil, models.ErrSelfDelete
+	}
+	if target.IsStaff() {
+		return nil, models.ErrStaffDelete
+	}
+
+	existing, err := s.erasureRepository.FindOpenByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, models.ErrErasureAlreadyRequested
+	}
+
+	now := time.Now()
+	request := &Request{
+		UserID:       userID,
+		Reason:       reason,
+		Status:       StatusScheduled,
+		RequestedBy:  *actor,
+		RequestedAt:  now,
+		ScheduledFor: now.Add(time.Duration(s.cfg.Jobs.Erasure.GracePeriodHours) * time.Hour),
+	}
+	if err := s.erasureRepository.Insert(ctx, request); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionRequestErasure,
+		TargetUserID: userID,
+		TargetRole:   target.Role,
+		Metadata: map[string]string{
+			"erasure_id":    request.ID.Hex(),
+			"reason":        reason,
+			"scheduled_for": request.ScheduledFor.UTC().Format(time.RFC3339),
+		},
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"erasure_id": request.ID.Hex(), "user_id": userID, "scheduled_for": request.ScheduledFor, "actor_id": actor.ID,
+	}).Info("User erasure scheduled")
+
+	return request, nil
+}
+
+func (s *erasureService) GetByID(ctx context.Context, id string) (*Request, error) {
+	requestID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+	return s.erasureRepository.GetByID(ctx, requestID)
+}
+
+// Cancel withdraws a scheduled erasure during the grace period
+func (s *erasureService) Cancel(ctx context.Context, id string, actor *models.Actor) (*Request, error) {
+	requestID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	if _, err := s.erasureRepository.GetByID(ctx, requestID); err != nil {
+		return nil, err
+	}
+
+	request, err := s.erasureRepository.Cancel(ctx, requestID, actor, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionCancelErasure,
+		TargetUserID: request.UserID,
+		Metadata:     map[string]string{"erasure_id": id},
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"erasure_id": id, "user_id": request.UserID, "actor_id": actor.ID,
+	}).Info("User erasure cancelled")
+
+	return request, nil
+}
+
+func (s *erasureService) GetCertificate(ctx context.Context, requestID string) (*Certificate, error) {
+	if _, err := primitive.ObjectIDFromHex(requestID); err != nil {
+		return nil, models.ErrInvalidParams
+	}
+	return s.certificateRepository.GetByRequestID(ctx, requestID)
+}
+
+// RunNextDue erases the user of the oldest due request. It reports whether a request was found.
+// A failed erasure is retried with a growing delay until the configured attempts are used up.
+func (s *erasureService) RunNextDue(ctx context.Context) (bool, error) {
+	now := time.Now()
+	lease := time.Duration(s.cfg.Jobs.Erasure.LeaseMinutes) * time.Minute
+	request, err := s.erasureRepository.ClaimDue(ctx, now, now.Add(-lease))
+	if err != nil || request == nil {
+		return false, err
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"erasure_id": request.ID.Hex(), "user_id": request.UserID, "attempt": request.Attempts,
+	})
+
+	certificate, err := s.erase(ctx, request)
+	if err != nil {
+		return true, s.handleFailure(ctx, request, err)
+	}
+
+	if err := s.erasureRepository.Finish(ctx, request.ID, StatusCompleted, "", certificate.ErasedAt); err != nil {
+		return true, err
+	}
+
+	logger.Info("User erasure completed")
+	return true, nil
+}
+
+// erasureStep removes personal data of the user from one store and returns how many
+// records it changed; running a step again only changes what is left
+type erasureStep struct {
+	field string
+	run   func(ctx context.Context, userID string) (int64, error)
+}
+
+func (s *erasureService) steps() []erasureStep {
+	return []erasureStep{
+		{field: progressActivityDeleted, run: s.activityService.DeleteUserActivity},
+		{field: progressAuditAnonymized, run: func(ctx context.Context, userID string) (int64, error) {
+			return s.auditService.AnonymizeTarget(ctx, userID, ErasedPlaceholder)
+		}},
+		{field: progressApprovals, run: func(ctx context.Context, userID string) (int64, error) {
+			return s.approvalService.AnonymizeTarget(ctx, userID, ErasedPlaceholder)
+		}},
+		{field: progressBulkJobs, run: s.userService.AnonymizeBulkJobs},
+		{field: progressSessions, run: s.sessionService.AnonymizeUserSessions},
+		{field: progressExports, run: s.exportService.DeleteUserExports},
+		{field: progressOutbox, run: s.outboxService.DeleteRelayed},
+		{field: progressDeadLetters, run: s.deadLetterService.DeleteUserMessages},
+	}
+}
+
+// erase anonymizes the user, removes the activity trail, exports and relayed messages
+// and scrubs personal data kept in audit, approval, bulk job and session records, then
+// issues the erasure certificate. Every step is safe to repeat, so a retry picks up
+// after the step that failed.
+func (s *erasureService) erase(ctx context.Context, request *Request) (*Certificate, error) {
+	actor := &models.Actor{ID: models.SystemActorID, Email: s.cfg.App.Name}
+
+	erasedFields, err := s.userService.AnonymizeUser(ctx, request.UserID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range s.steps() {
+		count, err := step.run(ctx, request.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.erasureRepository.AddProgress(ctx, request.ID, step.field, count); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reload to get the counters summed over all attempts
+	request, err = s.erasureRepository.GetByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &Certificate{
+		RequestID:    request.ID.Hex(),
+		UserID:       request.UserID,
+		ErasedFields: erasedFields,
+		Progress:     request.Progress,
+		RequestedBy:  request.RequestedBy.ID,
+		RequestedAt:  request.RequestedAt,
+		ErasedAt:     time.Now(),
+	}
+	if err := s.certificateRepository.Insert(ctx, certificate); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionEraseUser,
+		TargetUserID: request.UserID,
+		Metadata: map[string]string{
+			"erasure_id":       request.ID.Hex(),
+			"certificate_id":   certificate.ID.Hex(),
+			"activity_deleted": strconv.FormatInt(certificate.ActivityDeleted, 10),
+		},
+	})
+
+	return certificate, nil
+}
+
+// handleFailure schedules the erasure again with exponential backoff, or marks it failed
+// once the configured attempts are used up
+func (s *erasureService) handleFailure(ctx context.Context, request *Request, cause error) error {
+	logger := logrus.WithError(cause).WithFields(logrus.Fields{
+		"erasure_id": request.ID.Hex(), "user_id": request.UserID, "attempt": request.Attempts,
+	})
+
+	if request.Attempts >= s.cfg.Jobs.Erasure.MaxAttempts {
+		logger.Error("User erasure failed permanently")
+		return s.erasureRepository.Finish(ctx, request.ID, StatusFailed, cause.Error(), time.Now())
+	}
+
+	delay := time.Duration(s.cfg.Jobs.Erasure.RetryDelaySeconds) * time.Second
+	for i := 1; i < request.Attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	logger.WithField("retry_in", delay).Warn("User erasure failed, retrying")
+	return s.erasureRepository.Reschedule(ctx, request.ID, cause.Error(), time.Now().Add(delay))
+}
+
+// recordAudit writes an audit entry; the change has already been applied,
+// so a failure here is logged rather than returned to the caller
+func (s *erasureService) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":         entry.Action,
+			"target_user_id": entry.TargetUserID,
+		}).Error("Failed to record audit entry")
+	}
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Version of the envelope and payload schemas; bumped on breaking changes
+const Version = 1
+
+// Event type constants
+const (
+	TypeUserActivated   = "user.activated"
+	TypeUserDeactivated = "user.deactivated"
+	TypeUserSuspended   = "user.suspended"
+	TypeUserRoleChanged = "user.role_changed"
+	TypeUserDeleted     = "user.deleted"
+)
+
+// Envelope wraps every domain event published by the service
+type Envelope struct {
+	EventID    string      `json:"event_id"`
+	Type       string      `json:"type"`
+	Version    int         `json:"version"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Actor      Actor       `json:"actor"`
+	Payload    interface{} `json:"payload"`
+}
+
+// Actor identifies who triggered the event without exposing personal data
+type Actor struct {
+	ID   string `json:"id"`
+	Role string `json:"role,omitempty"`
+}
+
+// UserStatusPayload is the payload of user.activated and user.deactivated
+type UserStatusPayload struct {
+	UserID         string `json:"user_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+}
+
+// UserSuspendedPayload is the payload of user.suspended
+type UserSuspendedPayload struct {
+	UserID         string     `json:"user_id"`
+	PreviousStatus string     `json:"previous_status"`
+	Reason         string     `json:"reason,omitempty"`
+	Until          *time.Time `json:"until,omitempty"`
+}
+
+// UserRoleChangedPayload is the payload of user.role_changed
+type UserRoleChangedPayload struct {
+	UserID       string `json:"user_id"`
+	PreviousRole string `json:"previous_role"`
+	Role         string `json:"role"`
+}
+
+// UserDeletedPayload is the payload of user.deleted
+type UserDeletedPayload struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// New builds an envelope with a unique event ID
+func New(eventType string, actor *models.Actor, payload interface{}) *Envelope {
+	return &Envelope{
+		EventID:    primitive.NewObjectID().Hex(),
+		Type:       eventType,
+		Version:    Version,
+		OccurredAt: time.Now().UTC(),
+		Actor:      Actor{ID: actor.ID, Role: actor.Role},
+		Payload:    payload,
+	}
+}
+
+// RoutingKey returns the routing key configured for the event type, the type itself if none is set
+func RoutingKey(queues *config.QueuesConfig, eventType string) string {
+	var routingKey string
+	switch eventType {
+	case TypeUserActivated:
+		routingKey = queues.UserActivated.RoutingKey
+	case TypeUserDeactivated:
+		routingKey = queues.UserDeactivated.RoutingKey
+	case TypeUserSuspended:
+		routingKey = queues.UserSuspended.RoutingKey
+	case TypeUserRoleChanged:
+		routingKey = queues.UserRoleChanged.RoutingKey
+	case TypeUserDeleted:
+		routingKey = queues.UserDeleted.RoutingKey
+	}
+
+	if routingKey == "" {
+		return eventType
+	}
+	return routingKey
+}
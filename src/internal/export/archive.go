@@ -0,0 +1,103 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeArchive stores the archive in the export directory and returns its path and size.
+// The file is written under a temporary name first so a partial file is never served.
+func writeArchive(dir string, export *Export, archive *Archive) (string, int64, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", 0, err
+	}
+
+	path := archivePath(dir, export)
+	tmpPath := tempPath(dir, export)
+
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if export.Format == FormatZIP {
+		err = writeZIP(file, archive)
+	} else {
+		err = writeJSON(file, archive)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", 0, err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", 0, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", 0, err
+	}
+	return path, info.Size(), nil
+}
+
+func archivePath(dir string, export *Export) string {
+	return filepath.Join(dir, export.ID.Hex()+"."+export.Format)
+}
+
+// tempPath is where the archive is written before it is complete
+func tempPath(dir string, export *Export) string {
+	return archivePath(dir, export) + ".tmp"
+}
+
+// writeZIP stores each section of the archive as a separate JSON file
+func writeZIP(w io.Writer, archive *Archive) error {
+	zipWriter := zip.NewWriter(w)
+
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", archive.Profile},
+		{"sessions.json", archive.Sessions},
+		{"activity.json", archive.Activity},
+		{"audit.json", archive.Audit},
+		{"manifest.json", map[string]interface{}{"generatedAt": archive.GeneratedAt, "userId": archive.Profile.ID}},
+	}
+
+	for _, section := range sections {
+		entry, err := zipWriter.Create(section.name)
+		if err != nil {
+			return err
+		}
+		if err := writeJSON(entry, section.data); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func writeJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// removeFile deletes an export file, a file that is already gone is not an error
+func removeFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
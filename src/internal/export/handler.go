@@ -0,0 +1,129 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	RequestExport(c *gin.Context)
+	GetExport(c *gin.Context)
+	DownloadExport(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) RequestExport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req CreateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logrus.WithError(err).Warn("Invalid export request body")
+			h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+	}
+
+	userID := c.Param("id")
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "format": req.Format,
+	}).Info("RequestExport request received")
+
+	export, err := h.service.RequestExport(ctx, userID, &req, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, userID, err, "Failed to request user export")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    export,
+		"message": "User export queued successfully",
+	})
+}
+
+func (h *handler) GetExport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	exportID := c.Param("id")
+	logrus.WithField("export_id", exportID).Info("GetExport request received")
+
+	export, err := h.service.GetByID(ctx, exportID)
+	if err != nil {
+		h.handleError(c, exportID, err, "Failed to retrieve export")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    export,
+		"message": "Export retrieved successfully",
+	})
+}
+
+func (h *handler) DownloadExport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	exportID := c.Param("id")
+	logrus.WithField("export_id", exportID).Info("DownloadExport request received")
+
+	export, err := h.service.GetForDownload(ctx, exportID, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, exportID, err, "Failed to download export")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.FileAttachment(export.FilePath, export.FileName())
+}
+
+func (h *handler) handleError(c *gin.Context, id string, err error, message string) {
+	logrus.WithError(err).WithField("id", id).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Please provide a valid ID")
+	case errors.Is(err, models.ErrInvalidExportFormat):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid parameters", "Format must be json or zip")
+	case errors.Is(err, models.ErrUserNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "User not found", "No user found with the provided ID")
+	case errors.Is(err, models.ErrExportNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Export not found", "No export found with the provided ID")
+	case errors.Is(err, models.ErrExportNotReady):
+		h.sendErrorResponse(c, http.StatusConflict, "Export not ready", err.Error())
+	case errors.Is(err, models.ErrExportExpired):
+		h.sendErrorResponse(c, http.StatusGone, "Export expired", "Please request a new export")
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
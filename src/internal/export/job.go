@@ -0,0 +1,100 @@
+package export
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Job generates queued exports and removes expired export files
+type Job struct {
+	service  Service
+	cfg      *config.JobConfig
+	timeout  time.Duration
+	stop     chan struct{}
+	finished chan struct{}
+}
+
+func NewJob(service Service, cfg *config.Configuration) *Job {
+	return &Job{
+		service:  service,
+		cfg:      &cfg.Jobs.Export.JobConfig,
+		timeout:  time.Duration(cfg.App.Timeout) * time.Second,
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+// Start runs the job in the background until Stop is called
+func (j *Job) Start() {
+	if !j.cfg.Enabled {
+		logrus.Info("Export job is disabled")
+		close(j.finished)
+		return
+	}
+
+	interval := time.Duration(j.cfg.IntervalSeconds) * time.Second
+	logrus.WithField("interval", interval).Info("Starting export job")
+
+	go func() {
+		defer close(j.finished)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				j.run()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop signals the job to exit and waits for the current export to finish
+func (j *Job) Stop() {
+	close(j.stop)
+	<-j.finished
+	logrus.Info("Export job stopped")
+}
+
+func (j *Job) run() {
+	for i := 0; i < j.cfg.BatchSize; i++ {
+		found, err := j.runNext()
+		if err != nil {
+			logrus.WithError(err).Error("Export job failed")
+			break
+		}
+		if !found {
+			break
+		}
+	}
+
+	j.purge()
+}
+
+func (j *Job) runNext() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	return j.service.RunNextExport(ctx)
+}
+
+func (j *Job) purge() {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	purged, err := j.service.PurgeExpired(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to purge expired exports")
+		return
+	}
+
+	if purged > 0 {
+		logrus.WithField("purged", purged).Info("Removed expired export files")
+	}
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/session"
+	"handyhub-admin-svc/src/internal/user"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Export status constants
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusExpired   = "expired"
+)
+
+// Export format constants
+const (
+	FormatJSON = "json"
+	FormatZIP  = "zip"
+)
+
+// Export is a subject-access archive of a single user generated in the background
+type Export struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID      string             `json:"userId" bson:"user_id"`
+	Format      string             `json:"format" bson:"format"`
+	Status      string             `json:"status" bson:"status"`
+	RequestedBy models.Actor       `json:"requestedBy" bson:"requested_by"`
+	FilePath    string             `json:"-" bson:"file_path,omitempty"`
+	FileSize    int64              `json:"fileSize,omitempty" bson:"file_size,omitempty"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+	Attempts    int                `json:"attempts" bson:"attempts"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+	StartedAt   *time.Time         `json:"startedAt,omitempty" bson:"started_at,omitempty"`
+	LeaseUntil  *time.Time         `json:"-" bson:"lease_until,omitempty"`
+	CompletedAt *time.Time         `json:"completedAt,omitempty" bson:"completed_at,omitempty"`
+	ExpiresAt   *time.Time         `json:"expiresAt,omitempty" bson:"expires_at,omitempty"`
+	DownloadURL string             `json:"downloadUrl" bson:"-"`
+}
+
+// CreateRequest represents request body for requesting a user export
+type CreateRequest struct {
+	Format string `json:"format"`
+}
+
+// Archive is the content of an export
+type Archive struct {
+	GeneratedAt time.Time                 `json:"generatedAt"`
+	Profile     *user.Details             `json:"profile"`
+	Sessions    []*session.Details        `json:"sessions"`
+	Activity    []*models.ActivityMessage `json:"activity"`
+	Audit       []*audit.Entry            `json:"audit"`
+}
+
+// FileName is the name offered to the browser when downloading the export
+func (e *Export) FileName() string {
+	return "user-" + e.UserID + "-export." + e.Format
+}
+
+func isValidFormat(format string) bool {
+	return format == FormatJSON || format == FormatZIP
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, export *Export) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Export, error)
+	ClaimNext(ctx context.Context, now, leaseUntil time.Time) (*Export, error)
+	Complete(ctx context.Context, id primitive.ObjectID, filePath string, fileSize int64, at, expiresAt time.Time) error
+	Fail(ctx context.Context, id primitive.ObjectID, errMessage string, at time.Time) error
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*Export, error)
+	MarkExpired(ctx context.Context, id primitive.ObjectID) error
+	FindByUser(ctx context.Context, userID string) ([]*Export, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type exportRepository struct {
+	Collection mongo.Collection
+}
+
+func NewExportRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &exportRepository{
+		Collection: collection,
+	}
+}
+
+func (r *exportRepository) Insert(ctx context.Context, export *Export) error {
+	result, err := r.Collection.InsertOne(ctx, export)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", export.UserID).Error("Failed to insert export")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		export.ID = id
+	}
+	return nil
+}
+
+func (r *exportRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*Export, error) {
+	var export Export
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&export); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrExportNotFound
+		}
+		logrus.WithError(err).WithField("export_id", id.Hex()).Error("Failed to get export")
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ClaimNext atomically marks the oldest queued export as running until the lease ends, so
+// each export is generated once. A running export whose lease ran out was interrupted and
+// is claimed again.
+func (r *exportRepository) ClaimNext(ctx context.Context, now, leaseUntil time.Time) (*Export, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"status": StatusQueued},
+		{"status": StatusRunning, "lease_until": bson.M{"$lte": now}},
+		{"status": StatusRunning, "lease_until": bson.M{"$exists": false}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": StatusRunning, "started_at": now, "lease_until": leaseUntil},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"created_at": 1}).
+		SetReturnDocument(options.After)
+
+	var export Export
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&export); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		logrus.WithError(err).Error("Failed to claim export")
+		return nil, err
+	}
+	return &export, nil
+}
+
+// Complete stores the generated file on the running export. It returns ErrExportNotFound
+// when the export is gone, e.g. deleted by an erasure while it was generated.
+func (r *exportRepository) Complete(ctx context.Context, id primitive.ObjectID, filePath string, fileSize int64, at, expiresAt time.Time) error {
+	filter := bson.M{"_id": id, "status": StatusRunning}
+	update := bson.M{"$set": bson.M{
+		"status":       StatusCompleted,
+		"file_path":    filePath,
+		"file_size":    fileSize,
+		"completed_at": at,
+		"expires_at":   expiresAt,
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithField("export_id", id.Hex()).Error("Failed to complete export")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrExportNotFound
+	}
+	return nil
+}
+
+func (r *exportRepository) Fail(ctx context.Context, id primitive.ObjectID, errMessage string, at time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"status":       StatusFailed,
+		"error":        errMessage,
+		"completed_at": at,
+	}}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("export_id", id.Hex()).Error("Failed to mark export as failed")
+		return err
+	}
+	return nil
+}
+
+// FindExpired returns completed exports whose files should be removed
+func (r *exportRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*Export, error) {
+	filter := bson.M{
+		"status":     StatusCompleted,
+		"expires_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.M{"expires_at": 1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find expired exports")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	exports := make([]*Export, 0, limit)
+	if err := cursor.All(ctx, &exports); err != nil {
+		logrus.WithError(err).Error("Failed to decode expired exports")
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *exportRepository) MarkExpired(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set":   bson.M{"status": StatusExpired},
+		"$unset": bson.M{"file_path": ""},
+	}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("export_id", id.Hex()).Error("Failed to mark export as expired")
+		return err
+	}
+	return nil
+}
+
+func (r *exportRepository) FindByUser(ctx context.Context, userID string) ([]*Export, error) {
+	cursor, err := r.Collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to find user exports")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	exports := make([]*Export, 0)
+	if err := cursor.All(ctx, &exports); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to decode user exports")
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *exportRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := r.Collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		logrus.WithError(err).WithField("export_id", id.Hex()).Error("Failed to delete export")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handyhub-admin-svc/src/internal/activity"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/session"
+	"handyhub-admin-svc/src/internal/user"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const downloadPathPattern = "%s/api/v1/admin/exports/%s/download"
+
+const errExportInterrupted = "export was interrupted too many times"
+
+type Service interface {
+	RequestExport(ctx context.Context, userID string, req *CreateRequest, actor *models.Actor) (*Export, error)
+	GetByID(ctx context.Context, id string) (*Export, error)
+	GetForDownload(ctx context.Context, id string, actor *models.Actor) (*Export, error)
+	RunNextExport(ctx context.Context) (bool, error)
+	PurgeExpired(ctx context.Context) (int, error)
+	DeleteUserExports(ctx context.Context, userID string) (int64, error)
+}
+
+type exportService struct {
+	exportRepository Repository
+	userService      user.Service
+	sessionService   session.Service
+	activityService  activity.Service
+	auditService     audit.Service
+	cfg              *config.Configuration
+}
+
+func NewExportService(exportRepository Repository,
+	userService user.Service,
+	sessionService session.Service,
+	activityService activity.Service,
+	auditService audit.Service,
+	cfg *config.Configuration) Service {
+	return &exportService{
+		exportRepository: exportRepository,
+		userService:      userService,
+		sessionService:   sessionService,
+		activityService:  activityService,
+		auditService:     auditService,
+		cfg:              cfg,
+	}
+}
+
+// RequestExport queues the generation of a user's data archive
+func (s *exportService) RequestExport(ctx context.Context, userID string, req *CreateRequest, actor *models.Actor) (*Export, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = FormatJSON
+	}
+	if !isValidFormat(format) {
+		return nil, models.ErrInvalidExportFormat
+	}
+
+	if _, err := s.userService.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		UserID:      userID,
+		Format:      format,
+		Status:      StatusQueued,
+		RequestedBy: *actor,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.exportRepository.Insert(ctx, export); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionExportUser,
+		TargetUserID: userID,
+		Metadata:     map[string]string{"export_id": export.ID.Hex(), "format": format},
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"export_id": export.ID.Hex(), "user_id": userID, "format": format, "actor_id": actor.ID,
+	}).Info("User export queued")
+
+	return s.withDownloadURL(export), nil
+}
+
+func (s *exportService) GetByID(ctx context.Context, id string) (*Export, error) {
+	exportID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	export, err := s.exportRepository.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withDownloadURL(export), nil
+}
+
+// GetForDownload returns a completed, unexpired export and records who downloaded it
+func (s *exportService) GetForDownload(ctx context.Context, id string, actor *models.Actor) (*Export, error) {
+	export, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case export.Status == StatusExpired:
+		return nil, models.ErrExportExpired
+	case export.Status != StatusCompleted:
+		return nil, models.ErrExportNotReady
+	case export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt):
+		return nil, models.ErrExportExpired
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionDownloadExport,
+		TargetUserID: export.UserID,
+		Metadata:     map[string]string{"export_id": id},
+	})
+
+	return export, nil
+}
+
+// RunNextExport generates the oldest queued export, or an interrupted one once its lease
+// ran out. It reports whether an export was found.
+func (s *exportService) RunNextExport(ctx context.Context) (bool, error) {
+	now := time.Now()
+	lease := time.Duration(s.cfg.Jobs.Export.LeaseMinutes) * time.Minute
+	export, err := s.exportRepository.ClaimNext(ctx, now, now.Add(lease))
+	if err != nil || export == nil {
+		return false, err
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"export_id": export.ID.Hex(), "user_id": export.UserID, "attempt": export.Attempts,
+	})
+
+	// A previous run was interrupted, drop the partial file it left behind
+	if export.Attempts > 1 {
+		if err := removeFile(tempPath(s.cfg.Exports.Directory, export)); err != nil {
+			logger.WithError(err).Warn("Failed to remove partial export file")
+		}
+		if export.Attempts > s.cfg.Jobs.Export.MaxAttempts {
+			logger.Error("User export was interrupted too many times")
+			return true, s.exportRepository.Fail(ctx, export.ID, errExportInterrupted, time.Now())
+		}
+	}
+
+	filePath, fileSize, err := s.generate(ctx, export)
+	if err != nil {
+		logger.WithError(err).Error("User export failed")
+		return true, s.exportRepository.Fail(ctx, export.ID, err.Error(), time.Now())
+	}
+
+	completedAt := time.Now()
+	expiresAt := completedAt.Add(time.Duration(s.cfg.Exports.ExpirationHours) * time.Hour)
+	if err := s.exportRepository.Complete(ctx, export.ID, filePath, fileSize, completedAt, expiresAt); err != nil {
+		removeFile(filePath)
+		if errors.Is(err, models.ErrExportNotFound) {
+			logger.Warn("User export was deleted while it was generated, archive removed")
+			return true, nil
+		}
+		return true, err
+	}
+
+	logger.WithField("file_size", fileSize).Info("User export completed")
+	return true, nil
+}
+
+// PurgeExpired removes files of expired exports and returns how many were purged
+func (s *exportService) PurgeExpired(ctx context.Context) (int, error) {
+	exports, err := s.exportRepository.FindExpired(ctx, time.Now(), s.cfg.Jobs.Export.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, export := range exports {
+		if err := removeFile(export.FilePath); err != nil {
+			logrus.WithError(err).WithField("export_id", export.ID.Hex()).Error("Failed to remove export file")
+			continue
+		}
+		if err := s.exportRepository.MarkExpired(ctx, export.ID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// DeleteUserExports removes every export of an erased user together with its archive file.
+// An export that is being generated finds itself deleted on completion and removes its file.
+func (s *exportService) DeleteUserExports(ctx context.Context, userID string) (int64, error) {
+	exports, err := s.exportRepository.FindByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, export := range exports {
+		if err := removeFile(export.FilePath); err != nil {
+			return deleted, err
+		}
+		if err := s.exportRepository.Delete(ctx, export.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// generate collects the user's data and writes the archive file
+func (s *exportService) generate(ctx context.Context, export *Export) (string, int64, error) {
+	archive, err := s.collect(ctx, export.UserID)
+	if err != nil {
+		return "", 0, err
+	}
+	return writeArchive(s.cfg.Exports.Directory, export, archive)
+}
+
+// collect assembles everything the service stores about the user
+func (s *exportService) collect(ctx context.Context, userID string) (*Archive, error) {
+	profile, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionService.GetAllUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activityEvents, err := s.collectActivity(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	auditEntries, err := s.collectAudit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Archive{
+		GeneratedAt: time.Now(),
+		Profile:     profile,
+		Sessions:    sessions,
+		Activity:    activityEvents,
+		Audit:       auditEntries,
+	}, nil
+}
+
+func (s *exportService) collectActivity(ctx context.Context, userID string) ([]*models.ActivityMessage, error) {
+	events := make([]*models.ActivityMessage, 0)
+	for page := 1; ; page++ {
+		timeline, err := s.activityService.GetTimeline(ctx, &activity.TimelineRequest{
+			UserID: userID,
+			Page:   page,
+			Limit:  s.cfg.Search.MaxQueryLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, timeline.Events...)
+		if page >= timeline.TotalPages {
+			return events, nil
+		}
+	}
+}
+
+func (s *exportService) collectAudit(ctx context.Context, userID string) ([]*audit.Entry, error) {
+	entries := make([]*audit.Entry, 0)
+	req := &audit.ListRequest{TargetUserID: userID, Limit: s.cfg.Search.MaxQueryLimit}
+	for {
+		page, err := s.auditService.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, page.Entries...)
+		if page.NextCursor == "" {
+			return entries, nil
+		}
+		req.Cursor = page.NextCursor
+	}
+}
+
+func (s *exportService) withDownloadURL(export *Export) *Export {
+	export.DownloadURL = fmt.Sprintf(downloadPathPattern, s.cfg.App.HostLink, export.ID.Hex())
+	return export
+}
+
+// recordAudit writes an audit entry; a failure here is logged rather than returned to the caller
+func (s *exportService) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":         entry.Action,
+			"target_user_id": entry.TargetUserID,
+		}).Error("Failed to record audit entry")
+	}
+}
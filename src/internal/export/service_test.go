@@ -0,0 +1,159 @@
+package export
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/activity"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/session"
+	"handyhub-admin-svc/src/internal/user"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeRepository hands out one claimed export and records how it ends
+type fakeRepository struct {
+	Repository
+	export     *Export
+	deleted    bool
+	leaseUntil time.Time
+}
+
+func (r *fakeRepository) ClaimNext(_ context.Context, _, leaseUntil time.Time) (*Export, error) {
+	r.leaseUntil = leaseUntil
+	claimed := *r.export
+	return &claimed, nil
+}
+
+func (r *fakeRepository) Complete(_ context.Context, _ primitive.ObjectID, filePath string, _ int64, _, _ time.Time) error {
+	if r.deleted {
+		return models.ErrExportNotFound
+	}
+	r.export.Status = StatusCompleted
+	r.export.FilePath = filePath
+	return nil
+}
+
+func (r *fakeRepository) Fail(_ context.Context, _ primitive.ObjectID, errMessage string, _ time.Time) error {
+	r.export.Status = StatusFailed
+	r.export.Error = errMessage
+	return nil
+}
+
+type fakeUserService struct {
+	user.Service
+}
+
+func (s *fakeUserService) GetUserByID(context.Context, string) (*user.Details, error) {
+	return &user.Details{}, nil
+}
+
+type fakeSessionService struct {
+	session.Service
+}
+
+func (s *fakeSessionService) GetAllUserSessions(context.Context, string) ([]*session.Details, error) {
+	return nil, nil
+}
+
+type fakeActivityService struct {
+	activity.Service
+}
+
+func (s *fakeActivityService) GetTimeline(context.Context, *activity.TimelineRequest) (*activity.TimelineResponse, error) {
+	return &activity.TimelineResponse{}, nil
+}
+
+type fakeAuditService struct {
+	audit.Service
+}
+
+func (s *fakeAuditService) List(context.Context, *audit.ListRequest) (*audit.ListResponse, error) {
+	return &audit.ListResponse{}, nil
+}
+
+func TestRunNextExport(t *testing.T) {
+	tests := []struct {
+		name        string
+		attempts    int
+		partial     bool
+		deleted     bool
+		wantStatus  string
+		wantArchive bool
+	}{
+		{
+			name:        "queued export is generated",
+			attempts:    1,
+			wantStatus:  StatusCompleted,
+			wantArchive: true,
+		},
+		{
+			name:        "interrupted export is generated again without its partial file",
+			attempts:    2,
+			partial:     true,
+			wantStatus:  StatusCompleted,
+			wantArchive: true,
+		},
+		{
+			name:       "export interrupted too many times fails",
+			attempts:   4,
+			partial:    true,
+			wantStatus: StatusFailed,
+		},
+		{
+			name:       "export deleted while it was generated leaves no archive",
+			attempts:   1,
+			deleted:    true,
+			wantStatus: StatusRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Configuration{}
+			cfg.Exports = config.ExportsConfig{Directory: t.TempDir(), ExpirationHours: 72}
+			cfg.Jobs.Export = config.ExportJobConfig{LeaseMinutes: 10, MaxAttempts: 3}
+
+			export := &Export{
+				ID:       primitive.NewObjectID(),
+				UserID:   primitive.NewObjectID().Hex(),
+				Format:   FormatJSON,
+				Status:   StatusRunning,
+				Attempts: tt.attempts,
+			}
+			if tt.partial {
+				if err := os.WriteFile(tempPath(cfg.Exports.Directory, export), []byte("{"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			repository := &fakeRepository{export: export, deleted: tt.deleted}
+			s := NewExportService(repository, &fakeUserService{}, &fakeSessionService{},
+				&fakeActivityService{}, &fakeAuditService{}, cfg)
+
+			before := time.Now()
+			found, err := s.RunNextExport(context.Background())
+			if err != nil || !found {
+				t.Fatalf("RunNextExport() = %v, %v, want true, nil", found, err)
+			}
+
+			if lease := repository.leaseUntil.Sub(before); lease < 10*time.Minute || lease > 11*time.Minute {
+				t.Errorf("lease = %v, want 10m", lease)
+			}
+			if export.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", export.Status, tt.wantStatus)
+			}
+			if _, err := os.Stat(tempPath(cfg.Exports.Directory, export)); !os.IsNotExist(err) {
+				t.Errorf("partial file still exists: %v", err)
+			}
+			_, err = os.Stat(archivePath(cfg.Exports.Directory, export))
+			if hasArchive := err == nil; hasArchive != tt.wantArchive {
+				t.Errorf("archive exists = %v, want %v", hasArchive, tt.wantArchive)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"handyhub-admin-svc/src/clients"
 	"handyhub-admin-svc/src/internal/cache"
 	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/rbac"
 	"net/http"
 	"strings"
 	"time"
@@ -23,32 +24,56 @@ type Claims struct {
 	Email     string `json:"email"`
 	Role      string `json:"role"`
 	TokenType string `json:"tokenType"`
+	// ElevatedAt is set by the auth service after the user re-authenticated
+	ElevatedAt *jwt.NumericDate `json:"elevatedAt,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// APIKeyAuthenticator resolves raw API keys sent in the X-API-Key header
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKeyPrincipal, error)
+}
+
 // AuthMiddleware handles authentication and authorization
 type AuthMiddleware struct {
-	jwtSecret    string
-	cacheService cache.Service
-	authClient   *clients.AuthClient
+	verifier          *TokenVerifier
+	cacheService      cache.Service
+	authClient        *clients.AuthClient
+	activityPublisher *clients.ActivityPublisher
+	authorizer        *rbac.Authorizer
+	apiKeyService     APIKeyAuthenticator
 }
 
 const (
 	redisKeyPattern = "session:%s:%s" // session:userID:sessionID
+	apiKeyHeader    = "X-API-Key"
 )
 
 // NewAuthMiddleware creates new auth middleware with AuthClient
-func NewAuthMiddleware(jwtSecret string, cacheService cache.Service, authClient *clients.AuthClient) *AuthMiddleware {
+func NewAuthMiddleware(verifier *TokenVerifier,
+	cacheService cache.Service,
+	authClient *clients.AuthClient,
+	activityPublisher *clients.ActivityPublisher,
+	authorizer *rbac.Authorizer,
+	apiKeyService APIKeyAuthenticator) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtSecret:    jwtSecret,
-		cacheService: cacheService,
-		authClient:   authClient,
+		verifier:          verifier,
+		cacheService:      cacheService,
+		authClient:        authClient,
+		activityPublisher: activityPublisher,
+		authorizer:        authorizer,
+		apiKeyService:     apiKeyService,
 	}
 }
 
-// RequireAuth validates JWT token and session
+// RequireAuth validates JWT token and session, or an API key sent in X-API-Key
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rawKey := c.GetHeader(apiKeyHeader); rawKey != "" {
+			m.authenticateAPIKey(c, rawKey)
+			return
+		}
+
 		token := m.extractToken(c)
 		if token == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization token is required"})
@@ -92,8 +117,8 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	}
 }
 
-// RequireAdminRights checks if user has admin privileges
-func (m *AuthMiddleware) RequireAdminRights() gin.HandlerFunc {
+// RequirePermission checks if the user role grants the given permission
+func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRoleInterface, exists := c.Get("user_role")
 		if !exists {
@@ -111,23 +136,63 @@ func (m *AuthMiddleware) RequireAdminRights() gin.HandlerFunc {
 			return
 		}
 
-		if userRole != "admin" {
-			userID, _ := c.Get("user_id")
+		userID, _ := c.Get("user_id")
+		if !m.hasPermission(c, userRole, permission) {
 			logrus.WithFields(logrus.Fields{
-				"user_id": userID, "user_role": userRole,
-			}).Warn("User attempted to access admin endpoint without admin privileges")
+				"user_id": userID, "user_role": userRole, "permission": permission,
+			}).Warn("User attempted to access admin endpoint without required permission")
 
-			c.JSON(http.StatusForbidden, gin.H{"error": "Access forbidden - admin privileges required"})
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":      "Access forbidden - missing required permission",
+				"permission": permission,
+			})
 			c.Abort()
 			return
 		}
 
-		userID, _ := c.Get("user_id")
-		logrus.WithField("user_id", userID).Debug("Admin access granted")
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID, "permission": permission,
+		}).Debug("Permission granted")
 		c.Next()
 	}
 }
 
+// hasPermission checks API key scopes when present, otherwise the role permissions
+func (m *AuthMiddleware) hasPermission(c *gin.Context, role, permission string) bool {
+	if scopes, exists := c.Get("api_key_scopes"); exists {
+		granted, _ := scopes.([]string)
+		return rbac.GrantsPermission(granted, permission)
+	}
+	return m.authorizer.HasPermission(role, permission)
+}
+
+// authenticateAPIKey authenticates automation requests, no user session is involved
+func (m *AuthMiddleware) authenticateAPIKey(c *gin.Context, rawKey string) {
+	principal, err := m.apiKeyService.Authenticate(c.Request.Context(), rawKey)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidAPIKey) {
+			logrus.WithField("ip_address", c.ClientIP()).Warn("Invalid API key")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired API key"})
+		} else {
+			logrus.WithError(err).Error("API key validation failed")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "API key validation error"})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", models.APIKeyActorPrefix+principal.KeyID)
+	c.Set("user_email", "")
+	c.Set("user_role", models.APIKeyRole)
+	c.Set("api_key_scopes", principal.Scopes)
+
+	logrus.WithFields(logrus.Fields{
+		"key_id": principal.KeyID, "name": principal.Name, "action": m.getRouteAction(c),
+	}).Debug("API key authenticated successfully")
+
+	c.Next()
+}
+
 // extractToken extracts JWT token from Authorization header
 func (m *AuthMiddleware) extractToken(c *gin.Context) string {
 	authHeader := c.GetHeader("Authorization")
@@ -139,14 +204,9 @@ func (m *AuthMiddleware) extractToken(c *gin.Context) string {
 
 // validateJWTToken parses and validates JWT token
 func (m *AuthMiddleware) validateJWTToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
-		}
-		return []byte(m.jwtSecret), nil
-	})
-
+	token, err := m.verifier.Verify(tokenString, &Claims{})
 	if err != nil || !token.Valid {
+		logrus.WithError(err).Debug("JWT verification failed")
 		return nil, errors.New("invalid token")
 	}
 
@@ -195,13 +255,17 @@ func (m *AuthMiddleware) getRouteAction(c *gin.Context) string {
 	return "unknown_action"
 }
 
-// publishActivity publishes session activity to RabbitMQ
+// publishActivity hands session activity to the buffered publisher, it never blocks the request
 func (m *AuthMiddleware) publishActivity(userID, sessionID, ipAddress, userAgent, action string) {
-	err := m.authClient.PublishActivityWithDetails(
-		userID, sessionID, models.ServiceAdminAuth, action, ipAddress, userAgent)
-	if err != nil {
-		logrus.WithError(err).Warn("Failed to publish activity message")
-	}
+	m.activityPublisher.Publish(&models.ActivityMessage{
+		UserID:      userID,
+		SessionID:   sessionID,
+		ServiceName: models.ServiceAdminAuth,
+		Action:      action,
+		IPAddress:   ipAddress,
+		UserAgent:   userAgent,
+		Timestamp:   time.Now(),
+	})
 }
 
 // setUserContext stores user info in context
@@ -210,4 +274,7 @@ func (m *AuthMiddleware) setUserContext(c *gin.Context, claims *Claims) {
 	c.Set("session_id", claims.SessionID)
 	c.Set("user_email", claims.Email)
 	c.Set("user_role", claims.Role)
+	if claims.ElevatedAt != nil {
+		c.Set("elevated_at", claims.ElevatedAt.Time)
+	}
 }
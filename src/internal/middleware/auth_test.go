@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/rbac"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveWithContext runs handlers behind a handler that seeds the context the way
+// RequireAuth does, and returns the response status
+func serveWithContext(values map[string]interface{}, handlers ...gin.HandlerFunc) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	chain := []gin.HandlerFunc{func(c *gin.Context) {
+		for key, value := range values {
+			c.Set(key, value)
+		}
+	}}
+	chain = append(chain, handlers...)
+	chain = append(chain, func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/", chain...)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder.Code
+}
+
+func newTestAuthMiddleware() *AuthMiddleware {
+	cfg := &config.Configuration{}
+	cfg.Security.RBAC.Roles = map[string][]string{
+		"superadmin": {rbac.PermAll},
+		"admin":      {rbac.PermUsersRead, rbac.PermUsersSuspend},
+		"support":    {rbac.PermUsersRead},
+	}
+	return &AuthMiddleware{authorizer: rbac.NewAuthorizer(cfg)}
+}
+
+func TestRequirePermission(t *testing.T) {
+	m := newTestAuthMiddleware()
+
+	tests := []struct {
+		name       string
+		values     map[string]interface{}
+		permission string
+		want       int
+	}{
+		{
+			name:       "role grants permission",
+			values:     map[string]interface{}{"user_role": "admin"},
+			permission: rbac.PermUsersSuspend,
+			want:       http.StatusOK,
+		},
+		{
+			name:       "wildcard grants every permission",
+			values:     map[string]interface{}{"user_role": "superadmin"},
+			permission: rbac.PermSessionsRevoke,
+			want:       http.StatusOK,
+		},
+		{
+			name:       "role lacks permission",
+			values:     map[string]interface{}{"user_role": "support"},
+			permission: rbac.PermUsersSuspend,
+			want:       http.StatusForbidden,
+		},
+		{
+			name:       "unknown role",
+			values:     map[string]interface{}{"user_role": "client"},
+			permission: rbac.PermUsersRead,
+			want:       http.StatusForbidden,
+		},
+		{
+			name:       "unauthenticated request",
+			values:     map[string]interface{}{},
+			permission: rbac.PermUsersRead,
+			want:       http.StatusUnauthorized,
+		},
+		{
+			name:       "malformed role",
+			values:     map[string]interface{}{"user_role": 42},
+			permission: rbac.PermUsersRead,
+			want:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serveWithContext(tt.values, m.RequirePermission(tt.permission)); got != tt.want {
+				t.Errorf("RequirePermission(%q) status = %d, want %d", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirePermissionWithAPIKey(t *testing.T) {
+	m := newTestAuthMiddleware()
+
+	tests := []struct {
+		name       string
+		scopes     interface{}
+		permission string
+		want       int
+	}{
+		{
+			name:       "scope grants permission",
+			scopes:     []string{rbac.PermUsersRead, rbac.PermStatsRead},
+			permission: rbac.PermStatsRead,
+			want:       http.StatusOK,
+		},
+		{
+			name:       "wildcard scope grants every permission",
+			scopes:     []string{rbac.PermAll},
+			permission: rbac.PermSessionsRevoke,
+			want:       http.StatusOK,
+		},
+		{
+			name:       "scopes are checked instead of the role",
+			scopes:     []string{rbac.PermUsersRead},
+			permission: rbac.PermUsersSuspend,
+			want:       http.StatusForbidden,
+		},
+		{
+			name:       "malformed scopes grant nothing",
+			scopes:     "users:read",
+			permission: rbac.PermUsersRead,
+			want:       http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// API keys authenticate with a role as well; it must not widen the scopes
+			values := map[string]interface{}{"user_role": "superadmin", "api_key_scopes": tt.scopes}
+			if got := serveWithContext(values, m.RequirePermission(tt.permission)); got != tt.want {
+				t.Errorf("RequirePermission(%q) status = %d, want %d", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorFromContext builds the acting admin from values stored by setUserContext
+func ActorFromContext(c *gin.Context) *models.Actor {
+	return &models.Actor{
+		ID:        c.GetString("user_id"),
+		Email:     c.GetString("user_email"),
+		Role:      c.GetString("user_role"),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// StepUpRequiredCode tells the UI to prompt the admin for re-authentication
+const StepUpRequiredCode = "step_up_required"
+
+// RequireStepUp allows the request only if the token carries a recent elevated claim.
+// Must run after RequireAuth. API keys cannot step up and are always rejected.
+func (m *AuthMiddleware) RequireStepUp(maxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+
+		if _, isAPIKey := c.Get("api_key_scopes"); isAPIKey {
+			logrus.WithField("user_id", userID).Warn("API key used on step-up protected endpoint")
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Access forbidden - endpoint requires interactive re-authentication",
+			})
+			c.Abort()
+			return
+		}
+
+		elevatedAt, ok := c.Get("elevated_at")
+		if at, isTime := elevatedAt.(time.Time); !ok || !isTime || time.Since(at) > maxAge {
+			logrus.WithFields(logrus.Fields{
+				"user_id": userID, "action": m.getRouteAction(c),
+			}).Info("Step-up authentication required")
+
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":         "Recent re-authentication is required for this action",
+				"code":          StepUpRequiredCode,
+				"maxAgeSeconds": int(maxAge.Seconds()),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequireStepUp(t *testing.T) {
+	m := newTestAuthMiddleware()
+	maxAge := 5 * time.Minute
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   int
+	}{
+		{
+			name:   "recent elevation",
+			values: map[string]interface{}{"user_id": "admin", "elevated_at": time.Now().Add(-time.Minute)},
+			want:   http.StatusOK,
+		},
+		{
+			name:   "elevation older than max age",
+			values: map[string]interface{}{"user_id": "admin", "elevated_at": time.Now().Add(-maxAge - time.Second)},
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "token was never elevated",
+			values: map[string]interface{}{"user_id": "admin"},
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "malformed elevation",
+			values: map[string]interface{}{"user_id": "admin", "elevated_at": "2024-01-01T00:00:00Z"},
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name: "api keys cannot step up",
+			values: map[string]interface{}{
+				"user_id": "key", "api_key_scopes": []string{"*"}, "elevated_at": time.Now(),
+			},
+			want: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serveWithContext(tt.values, m.RequireStepUp(maxAge)); got != tt.want {
+				t.Errorf("RequireStepUp() status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/config"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+const defaultJWKSTimeout = 5 * time.Second
+
+// TokenVerifier verifies JWT signatures with a shared secret, a static public key or JWKS
+type TokenVerifier struct {
+	algorithm string
+	secret    []byte
+	publicKey interface{}
+	jwks      *clients.JWKSClient
+	timeout   time.Duration
+	parser    *jwt.Parser
+}
+
+// NewTokenVerifier creates token verifier from security settings
+func NewTokenVerifier(cfg *config.SecuritySettings) (*TokenVerifier, error) {
+	algorithm := cfg.JWT.Algorithm
+	if algorithm == "" {
+		algorithm = AlgorithmHS256
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{algorithm})}
+	if cfg.JWT.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWT.Issuer))
+	}
+	if cfg.JWT.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWT.Audience))
+	}
+
+	timeout := time.Duration(cfg.JWT.JWKSTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultJWKSTimeout
+	}
+
+	verifier := &TokenVerifier{
+		algorithm: algorithm,
+		timeout:   timeout,
+		parser:    jwt.NewParser(options...),
+	}
+
+	switch algorithm {
+	case AlgorithmHS256:
+		if cfg.JwtKey == "" {
+			return nil, errors.New("jwt-key is required for HS256")
+		}
+		verifier.secret = []byte(cfg.JwtKey)
+	case AlgorithmRS256, AlgorithmES256:
+		if err := verifier.setupPublicKeys(&cfg.JWT); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", algorithm)
+	}
+
+	return verifier, nil
+}
+
+func (v *TokenVerifier) setupPublicKeys(cfg *config.JWTConfig) error {
+	if cfg.JWKSURL != "" {
+		v.jwks = clients.NewJWKSClient(cfg.JWKSURL,
+			time.Duration(cfg.JWKSCacheMinutes)*time.Minute, v.timeout)
+		return nil
+	}
+
+	if cfg.PublicKeyFile == "" {
+		return fmt.Errorf("public-key-file or jwks-url is required for %s", v.algorithm)
+	}
+
+	pemData, err := os.ReadFile(cfg.PublicKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read public key file: %w", err)
+	}
+
+	if v.algorithm == AlgorithmRS256 {
+		v.publicKey, err = jwt.ParseRSAPublicKeyFromPEM(pemData)
+	} else {
+		v.publicKey, err = jwt.ParseECPublicKeyFromPEM(pemData)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return nil
+}
+
+// Verify parses token into claims and validates signature, expiry, issuer and audience
+func (v *TokenVerifier) Verify(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return v.parser.ParseWithClaims(tokenString, claims, v.keyFunc)
+}
+
+func (v *TokenVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	if v.secret != nil {
+		return v.secret, nil
+	}
+
+	if v.jwks == nil {
+		return v.publicKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token header is missing kid")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+	defer cancel()
+
+	return v.jwks.GetKey(ctx, kid)
+}
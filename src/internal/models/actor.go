@@ -0,0 +1,13 @@
+package models
+
+// SystemActorID identifies mutations performed by background jobs
+const SystemActorID = "system"
+
+// Actor identifies the admin performing a request
+type Actor struct {
+	ID        string `json:"id" bson:"id"`
+	Email     string `json:"email" bson:"email"`
+	Role      string `json:"role,omitempty" bson:"role,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty" bson:"ip_address,omitempty"`
+	UserAgent string `json:"userAgent,omitempty" bson:"user_agent,omitempty"`
+}
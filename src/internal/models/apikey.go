@@ -0,0 +1,14 @@
+package models
+
+// APIKeyActorPrefix prefixes actor IDs of requests authenticated with an API key
+const APIKeyActorPrefix = "apikey:"
+
+// APIKeyRole is the actor role of requests authenticated with an API key
+const APIKeyRole = "api_key"
+
+// APIKeyPrincipal identifies the API key a request was authenticated with
+type APIKeyPrincipal struct {
+	KeyID  string
+	Name   string
+	Scopes []string
+}
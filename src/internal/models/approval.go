@@ -0,0 +1,14 @@
+package models
+
+// ApprovalRequiredError is returned when an action was queued for a second admin
+type ApprovalRequiredError struct {
+	ApprovalID string
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	return ErrApprovalRequired.Error()
+}
+
+func (e *ApprovalRequiredError) Is(target error) bool {
+	return target == ErrApprovalRequired
+}
@@ -36,8 +36,79 @@ var (
 	ErrDuplicateEmail    = errors.New("user with this email already exists")
 	ErrDuplicatePhone    = errors.New("user with this phone already exists")
 	ErrInvalidParams     = errors.New("invalid parameters")
+	ErrInvalidTimeRange  = errors.New("invalid time range")
+	ErrInvalidInterval   = errors.New("invalid interval")
 	ErrInvalidUserStatus = errors.New("invalid user status")
 	ErrInvalidRole       = errors.New("invalid user role")
 	ErrUserInactive      = errors.New("user is inactive")
 	ErrEmailNotVerified  = errors.New("email is not verified")
+
+	ErrSuspensionReasonRequired = errors.New("suspension reason is required")
+	ErrInvalidSuspensionEnd     = errors.New("suspension end must be in the future")
+
+	ErrInvalidStatusTransition = errors.New("user status transition is not allowed")
+	ErrStatusConflict          = errors.New("user status was changed concurrently")
+	ErrSelfStatusChange        = errors.New("admins cannot change their own status")
+	ErrAdminStatusChange       = errors.New("staff accounts cannot be suspended or deactivated")
+
+	ErrDeletionReasonRequired = errors.New("deletion reason is required")
+	ErrSelfDelete             = errors.New("admins cannot delete their own account")
+	ErrStaffDelete            = errors.New("staff accounts cannot be deleted")
+	ErrUserNotDeleted         = errors.New("user is not deleted")
+	ErrUserErased             = errors.New("user personal data has been erased")
+
+	ErrRoleUnchanged       = errors.New("user already has this role")
+	ErrRoleConflict        = errors.New("user role was changed concurrently")
+	ErrSelfRoleChange      = errors.New("admins cannot change their own role")
+	ErrLastAdmin           = errors.New("the last active admin cannot be demoted")
+	ErrRoleChangeForbidden = errors.New("only superadmins can grant or revoke the superadmin role")
+)
+
+var (
+	ErrInvalidActivityMessage = errors.New("invalid activity message")
+)
+
+var (
+	ErrAPIKeyNotFound        = errors.New("api key not found")
+	ErrInvalidAPIKey         = errors.New("api key is invalid, expired or revoked")
+	ErrInvalidAPIKeyScope    = errors.New("unknown api key scope")
+	ErrAPIKeyScopeNotAllowed = errors.New("api key scope exceeds creator permissions")
+)
+
+var (
+	ErrApprovalRequired    = errors.New("action requires approval by a second admin")
+	ErrApprovalNotFound    = errors.New("approval request not found")
+	ErrApprovalNotPending  = errors.New("approval request is no longer pending")
+	ErrApprovalExpired     = errors.New("approval request has expired")
+	ErrSelfApproval        = errors.New("approval requests cannot be reviewed by their requester")
+	ErrApprovalNotAllowed  = errors.New("reviewer lacks permission for the requested action")
+	ErrApprovalUnsupported = errors.New("no executor registered for the requested action")
+)
+
+var (
+	ErrBulkTargetsRequired = errors.New("either user IDs or a filter is required")
+	ErrBulkTooManyUsers    = errors.New("bulk update exceeds the maximum number of users")
+	ErrBulkJobNotFound     = errors.New("bulk job not found")
+	ErrBulkNotProcessed    = errors.New("user was not processed before the request timed out")
+)
+
+var (
+	ErrErasureNotFound         = errors.New("erasure request not found")
+	ErrErasureAlreadyRequested = errors.New("erasure is already scheduled for this user")
+	ErrErasureNotCancellable   = errors.New("erasure request is no longer scheduled")
+	ErrErasureReasonRequired   = errors.New("erasure reason is required")
+	ErrCertificateNotFound     = errors.New("erasure certificate not found")
+)
+
+var (
+	ErrExportNotFound      = errors.New("export not found")
+	ErrExportNotReady      = errors.New("export is not ready yet")
+	ErrExportExpired       = errors.New("export has expired")
+	ErrInvalidExportFormat = errors.New("invalid export format")
+)
+
+var (
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	ErrDeadLetterReplayed = errors.New("dead letter has already been replayed")
+	ErrUnknownQueue       = errors.New("queue is not consumed by this service")
 )
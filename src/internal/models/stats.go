@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Stats struct {
 	Total        int64        `json:"total"`
 	Active       int64        `json:"active"`
@@ -27,3 +29,51 @@ type StatsResult struct {
 	Clients      int64 `bson:"clients"`
 	NewThisMonth int64 `bson:"newThisMonth"`
 }
+
+// Time series interval constants
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// TimeSeriesQuery describes a bucketed statistics range; To is exclusive
+type TimeSeriesQuery struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Interval string    `json:"interval"`
+}
+
+type TimeSeries struct {
+	TimeSeriesQuery
+	Buckets []*TimeSeriesBucket `json:"buckets"`
+}
+
+type TimeSeriesBucket struct {
+	Start         time.Time  `json:"start"`
+	Registrations RoleCounts `json:"registrations"`
+	Activations   RoleCounts `json:"activations"`
+	Suspensions   RoleCounts `json:"suspensions"`
+}
+
+type RoleCounts struct {
+	Total  int64            `json:"total"`
+	ByRole map[string]int64 `json:"byRole"`
+}
+
+// Add increments the total and the per role counter
+func (rc *RoleCounts) Add(role string, count int64) {
+	if rc.ByRole == nil {
+		rc.ByRole = make(map[string]int64)
+	}
+	rc.Total += count
+	rc.ByRole[role] += count
+}
+
+// TimeSeriesResult is a single aggregation row counted per bucket and role
+type TimeSeriesResult struct {
+	Bucket time.Time `bson:"bucket"`
+	Role   string    `bson:"role"`
+	Status string    `bson:"status,omitempty"`
+	Count  int64     `bson:"count"`
+}
@@ -0,0 +1,84 @@
+package outbox
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RelayJob drains the outbox to the exchange
+type RelayJob struct {
+	service  Service
+	cfg      *config.OutboxRelayJobConfig
+	timeout  time.Duration
+	stop     chan struct{}
+	finished chan struct{}
+}
+
+func NewRelayJob(service Service, cfg *config.Configuration) *RelayJob {
+	return &RelayJob{
+		service:  service,
+		cfg:      &cfg.Jobs.OutboxRelay,
+		timeout:  time.Duration(cfg.App.Timeout) * time.Second,
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+// Start runs the job in the background until Stop is called
+func (j *RelayJob) Start() {
+	if !j.cfg.Enabled {
+		logrus.Info("Outbox relay job is disabled")
+		close(j.finished)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	if err := j.service.EnsureIndexes(ctx); err != nil {
+		logrus.WithError(err).Warn("Outbox indexes are missing, published messages are not expired")
+	}
+	cancel()
+
+	interval := time.Duration(j.cfg.IntervalSeconds) * time.Second
+	logrus.WithField("interval", interval).Info("Starting outbox relay job")
+
+	go func() {
+		defer close(j.finished)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				j.run()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop signals the job to exit and waits for the current batch to finish
+func (j *RelayJob) Stop() {
+	close(j.stop)
+	<-j.finished
+	logrus.Info("Outbox relay job stopped")
+}
+
+func (j *RelayJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	published, err := j.service.RelayPending(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Outbox relay job failed")
+		return
+	}
+
+	if published > 0 {
+		logrus.WithField("published", published).Debug("Relayed outbox messages")
+	}
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"handyhub-admin-svc/src/clients"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LeaseRepository interface {
+	Acquire(ctx context.Context, name, owner string, now, until time.Time) (bool, error)
+}
+
+type leaseRepository struct {
+	Collection mongo.Collection
+}
+
+func NewLeaseRepository(mongoClient *clients.MongoDB, collectionName string) LeaseRepository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &leaseRepository{
+		Collection: collection,
+	}
+}
+
+// Acquire takes or renews the named lease until the given time. It reports false while
+// another owner holds an unexpired lease.
+func (r *leaseRepository) Acquire(ctx context.Context, name, owner string, now, until time.Time) (bool, error) {
+	filter := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"owner": owner},
+			{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": until}}
+
+	// The upsert creates the lease the first time; while it is held by another owner the
+	// filter does not match and the insert fails on the _id
+	if _, err := r.Collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		logrus.WithError(err).WithField("lease", name).Error("Failed to acquire lease")
+		return false, err
+	}
+	return true, nil
+}
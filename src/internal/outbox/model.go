@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Outbox message status constants
+const (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+)
+
+// Message is an event stored together with the change that produced it and
+// relayed to the exchange afterwards. Its ID doubles as the AMQP message ID,
+// so consumers can drop the duplicates a retried publish may produce.
+type Message struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	AggregateID   string             `bson:"aggregate_id"`
+	RoutingKey    string             `bson:"routing_key"`
+	Payload       []byte             `bson:"payload"`
+	Status        string             `bson:"status"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	PublishedAt   *time.Time         `bson:"published_at,omitempty"`
+}
+
+// MessageID is the idempotency key sent with the message
+func (m *Message) MessageID() string {
+	return m.ID.Hex()
+}
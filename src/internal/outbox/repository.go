@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"context"
+	"handyhub-admin-svc/src/clients"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	Insert(ctx context.Context, message *Message) error
+	FindPending(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+	MarkPublished(ctx context.Context, id primitive.ObjectID, at time.Time) error
+	MarkRetry(ctx context.Context, id primitive.ObjectID, errMessage string, nextAttemptAt time.Time) error
+	DeleteRelayed(ctx context.Context, aggregateID string) (int64, error)
+	EnsureIndexes(ctx context.Context, retention time.Duration) error
+}
+
+type outboxRepository struct {
+	Collection mongo.Collection
+}
+
+func NewOutboxRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &outboxRepository{
+		Collection: collection,
+	}
+}
+
+// Insert stores the message; called with a transaction context it commits or aborts with the change
+func (r *outboxRepository) Insert(ctx context.Context, message *Message) error {
+	if _, err := r.Collection.InsertOne(ctx, message); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"aggregate_id": message.AggregateID, "routing_key": message.RoutingKey,
+		}).Error("Failed to insert outbox message")
+		return err
+	}
+	return nil
+}
+
+// FindPending returns the oldest due messages in the order they were written. Aggregates
+// with a message waiting for a retry are left out entirely, so their later messages do
+// not overtake it and do not take up the batch.
+func (r *outboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*Message, error) {
+	blocked, err := r.Collection.Distinct(ctx, "aggregate_id", bson.M{
+		"status":          StatusPending,
+		"next_attempt_at": bson.M{"$gt": now},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find blocked outbox aggregates")
+		return nil, err
+	}
+
+	filter := bson.M{
+		"status":          StatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	if len(blocked) > 0 {
+		filter["aggregate_id"] = bson.M{"$nin": blocked}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find pending outbox messages")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := make([]*Message, 0, limit)
+	if err := cursor.All(ctx, &messages); err != nil {
+		logrus.WithError(err).Error("Failed to decode pending outbox messages")
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	update := bson.M{
+		"$set":   bson.M{"status": StatusPublished, "published_at": at},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"last_error": ""},
+	}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("message_id", id.Hex()).Error("Failed to mark outbox message as published")
+		return err
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkRetry(ctx context.Context, id primitive.ObjectID, errMessage string, nextAttemptAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"last_error": errMessage, "next_attempt_at": nextAttemptAt},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("message_id", id.Hex()).Error("Failed to schedule outbox message retry")
+		return err
+	}
+	return nil
+}
+
+// DeleteRelayed removes the published messages of the aggregate
+func (r *outboxRepository) DeleteRelayed(ctx context.Context, aggregateID string) (int64, error) {
+	filter := bson.M{
+		"aggregate_id": aggregateID,
+		"status":       bson.M{"$ne": StatusPending},
+	}
+
+	result, err := r.Collection.DeleteMany(ctx, filter)
+	if err != nil {
+		logrus.WithError(err).WithField("aggregate_id", aggregateID).Error("Failed to delete outbox messages")
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// EnsureIndexes creates the indexes the relay queries on and expires published messages
+// after the retention period
+func (r *outboxRepository) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "aggregate_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "published_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+		},
+	}
+
+	if _, err := r.Collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		logrus.WithError(err).Error("Failed to create outbox indexes")
+		return err
+	}
+	return nil
+}
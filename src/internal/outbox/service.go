@@ -0,0 +1,199 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const maxRetryDelay = 5 * time.Minute
+
+// relayLease is held by the one instance that relays the outbox
+const relayLease = "outbox-relay"
+
+type Service interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Enqueue(ctx context.Context, aggregateID, routingKey string, event interface{}) error
+	RelayPending(ctx context.Context) (int, error)
+	DeleteRelayed(ctx context.Context, aggregateID string) (int64, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type outboxService struct {
+	outboxRepository Repository
+	leaseRepository  LeaseRepository
+	mongodb          *clients.MongoDB
+	rabbitMQ         *clients.RabbitMQ
+	cfg              *config.Configuration
+	// owner identifies this instance as holder of the relay lease
+	owner string
+}
+
+func NewOutboxService(outboxRepository Repository,
+	leaseRepository LeaseRepository,
+	mongodb *clients.MongoDB,
+	rabbitMQ *clients.RabbitMQ,
+	cfg *config.Configuration) Service {
+	return &outboxService{
+		outboxRepository: outboxRepository,
+		leaseRepository:  leaseRepository,
+		mongodb:          mongodb,
+		rabbitMQ:         rabbitMQ,
+		cfg:              cfg,
+		owner:            primitive.NewObjectID().Hex(),
+	}
+}
+
+// RunInTransaction runs fn in a Mongo transaction; writes made with the context passed
+// to fn, including Enqueue, are committed or aborted together. fn may be retried on
+// transient errors, so it must only write through that context.
+func (s *outboxService) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := s.mongodb.Client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start mongo session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionCtx)
+	})
+	return err
+}
+
+// Enqueue stores the event for the relay. Events of the same aggregate are published in order.
+func (s *outboxService) Enqueue(ctx context.Context, aggregateID, routingKey string, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	now := time.Now()
+	return s.outboxRepository.Insert(ctx, &Message{
+		ID:            primitive.NewObjectID(),
+		AggregateID:   aggregateID,
+		RoutingKey:    routingKey,
+		Payload:       payload,
+		Status:        StatusPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	})
+}
+
+// RelayPending publishes one batch of due messages and returns how many were published.
+// Only the holder of the relay lease publishes, so replicas neither send the same message
+// twice nor reorder the messages of an aggregate. A message that waits for a retry holds
+// back the later messages of its aggregate.
+func (s *outboxService) RelayPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	leaseUntil := now.Add(time.Duration(s.cfg.Jobs.OutboxRelay.LeaseSeconds) * time.Second)
+	acquired, err := s.leaseRepository.Acquire(ctx, relayLease, s.owner, now, leaseUntil)
+	if err != nil || !acquired {
+		return 0, err
+	}
+
+	messages, err := s.outboxRepository.FindPending(ctx, now, s.cfg.Jobs.OutboxRelay.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := make(map[string]bool)
+	published := 0
+
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			return published, ctx.Err()
+		}
+		// Stop before another instance may have taken over the lease
+		if time.Now().After(leaseUntil) {
+			return published, nil
+		}
+		if blocked[message.AggregateID] {
+			continue
+		}
+
+		if err := s.publish(message); err != nil {
+			blocked[message.AggregateID] = true
+			if err := s.handleFailure(ctx, message, err); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := s.outboxRepository.MarkPublished(ctx, message.ID, time.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (s *outboxService) publish(message *Message) error {
+	return s.rabbitMQ.Publish(message.RoutingKey, amqp.Publishing{
+		MessageId:    message.MessageID(),
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         message.Payload,
+		Timestamp:    message.CreatedAt,
+	})
+}
+
+// handleFailure schedules a retry with exponential backoff. After the configured number
+// of attempts the message is parked: it is retried at the maximum delay and reported as
+// an error, while the later messages of its aggregate stay held back so none overtakes it.
+func (s *outboxService) handleFailure(ctx context.Context, message *Message, publishErr error) error {
+	attempts := message.Attempts + 1
+	logger := logrus.WithError(publishErr).WithFields(logrus.Fields{
+		"message_id": message.MessageID(), "aggregate_id": message.AggregateID,
+		"routing_key": message.RoutingKey, "attempts": attempts,
+	})
+
+	delay := s.retryDelay(attempts)
+	if s.parked(attempts) {
+		logger.WithField("retry_in", delay).Error("Outbox message parked, later messages of the aggregate are held back")
+	} else {
+		logger.WithField("retry_in", delay).Warn("Failed to relay outbox message")
+	}
+	return s.outboxRepository.MarkRetry(ctx, message.ID, publishErr.Error(), time.Now().Add(delay))
+}
+
+// retryDelay doubles the configured delay with every attempt up to maxRetryDelay;
+// parked messages are retried at maxRetryDelay
+func (s *outboxService) retryDelay(attempts int) time.Duration {
+	if s.parked(attempts) {
+		return maxRetryDelay
+	}
+
+	delay := time.Duration(s.cfg.Jobs.OutboxRelay.RetryDelaySeconds) * time.Second
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+func (s *outboxService) parked(attempts int) bool {
+	return attempts >= s.cfg.Jobs.OutboxRelay.MaxAttempts
+}
+
+// DeleteRelayed removes published messages of the aggregate, used when a user is erased;
+// pending messages are left for the relay
+func (s *outboxService) DeleteRelayed(ctx context.Context, aggregateID string) (int64, error) {
+	return s.outboxRepository.DeleteRelayed(ctx, aggregateID)
+}
+
+// EnsureIndexes prepares the outbox collection; published messages are kept for the
+// configured retention period
+func (s *outboxService) EnsureIndexes(ctx context.Context) error {
+	return s.outboxRepository.EnsureIndexes(ctx, time.Duration(s.cfg.Jobs.OutboxRelay.RetentionHours)*time.Hour)
+}
@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"testing"
+	"time"
+)
+
+// fakeRepository counts the batches the relay asks for
+type fakeRepository struct {
+	Repository
+	findCalls int
+}
+
+func (r *fakeRepository) FindPending(context.Context, time.Time, int) ([]*Message, error) {
+	r.findCalls++
+	return nil, nil
+}
+
+type fakeLeaseRepository struct {
+	acquired bool
+	err      error
+}
+
+func (r *fakeLeaseRepository) Acquire(context.Context, string, string, time.Time, time.Time) (bool, error) {
+	return r.acquired, r.err
+}
+
+func newTestOutboxService(repository Repository, lease LeaseRepository) *outboxService {
+	cfg := &config.Configuration{}
+	cfg.Jobs.OutboxRelay = config.OutboxRelayJobConfig{
+		JobConfig:         config.JobConfig{BatchSize: 100},
+		MaxAttempts:       5,
+		RetryDelaySeconds: 10,
+		LeaseSeconds:      30,
+	}
+	return NewOutboxService(repository, lease, nil, nil, cfg).(*outboxService)
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name              string
+		attempts          int
+		maxAttempts       int
+		retryDelaySeconds int
+		want              time.Duration
+	}{
+		{name: "first failure waits the base delay", attempts: 1, maxAttempts: 5, retryDelaySeconds: 10, want: 10 * time.Second},
+		{name: "delay doubles with every attempt", attempts: 2, maxAttempts: 5, retryDelaySeconds: 10, want: 20 * time.Second},
+		{name: "fourth failure", attempts: 4, maxAttempts: 5, retryDelaySeconds: 10, want: 80 * time.Second},
+		{name: "delay is capped", attempts: 9, maxAttempts: 20, retryDelaySeconds: 10, want: maxRetryDelay},
+		{name: "message is parked after the last attempt", attempts: 5, maxAttempts: 5, retryDelaySeconds: 10, want: maxRetryDelay},
+		{name: "missing base delay falls back to the cap", attempts: 1, maxAttempts: 5, retryDelaySeconds: 0, want: maxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestOutboxService(&fakeRepository{}, &fakeLeaseRepository{})
+			s.cfg.Jobs.OutboxRelay.MaxAttempts = tt.maxAttempts
+			s.cfg.Jobs.OutboxRelay.RetryDelaySeconds = tt.retryDelaySeconds
+
+			if got := s.retryDelay(tt.attempts); got != tt.want {
+				t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelayPendingLease(t *testing.T) {
+	leaseErr := errors.New("mongo unavailable")
+
+	tests := []struct {
+		name      string
+		lease     *fakeLeaseRepository
+		wantErr   error
+		wantFetch bool
+	}{
+		{name: "lease holder relays", lease: &fakeLeaseRepository{acquired: true}, wantFetch: true},
+		{name: "lease held by another instance", lease: &fakeLeaseRepository{}},
+		{name: "lease cannot be checked", lease: &fakeLeaseRepository{err: leaseErr}, wantErr: leaseErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &fakeRepository{}
+			s := newTestOutboxService(repository, tt.lease)
+
+			published, err := s.RelayPending(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RelayPending() error = %v, want %v", err, tt.wantErr)
+			}
+			if published != 0 {
+				t.Errorf("RelayPending() = %d, want 0", published)
+			}
+			if fetched := repository.findCalls > 0; fetched != tt.wantFetch {
+				t.Errorf("fetched pending messages = %v, want %v", fetched, tt.wantFetch)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package rbac
+
+import (
+	"handyhub-admin-svc/src/internal/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Authorizer resolves role permissions configured under security.rbac
+type Authorizer struct {
+	roles map[string]map[string]bool
+}
+
+func NewAuthorizer(cfg *config.Configuration) *Authorizer {
+	roles := make(map[string]map[string]bool, len(cfg.Security.RBAC.Roles))
+
+	for role, permissions := range cfg.Security.RBAC.Roles {
+		granted := make(map[string]bool, len(permissions))
+		for _, permission := range permissions {
+			if !IsKnownPermission(permission) {
+				logrus.WithFields(logrus.Fields{
+					"role": role, "permission": permission,
+				}).Warn("Unknown permission in RBAC configuration")
+			}
+			granted[permission] = true
+		}
+		roles[role] = granted
+	}
+
+	logrus.WithField("roles", len(roles)).Info("RBAC roles loaded")
+	return &Authorizer{roles: roles}
+}
+
+// HasPermission checks if the role is granted the permission
+func (a *Authorizer) HasPermission(role, permission string) bool {
+	granted, ok := a.roles[role]
+	if !ok {
+		return false
+	}
+	return granted[PermAll] || granted[permission]
+}
@@ -0,0 +1,68 @@
+package rbac
+
+// Permission constants
+const (
+	PermUsersRead       = "users:read"
+	PermUsersActivate   = "users:activate"
+	PermUsersDeactivate = "users:deactivate"
+	PermUsersSuspend    = "users:suspend"
+	PermUsersBulkUpdate = "users:bulk-update"
+	PermUsersChangeRole = "users:change-role"
+	PermUsersDelete     = "users:delete"
+	PermUsersRestore    = "users:restore"
+	PermUsersErase      = "users:erase"
+	PermUsersExport     = "users:export"
+	PermStatsRead       = "stats:read"
+	PermAuditRead       = "audit:read"
+	PermActivityRead    = "activity:read"
+	PermSessionsRead    = "sessions:read"
+	PermSessionsRevoke  = "sessions:revoke"
+	PermAPIKeysManage   = "apikeys:manage"
+	PermApprovalsReview = "approvals:review"
+	PermDLQManage       = "dlq:manage"
+
+	// PermAll grants every permission
+	PermAll = "*"
+)
+
+var knownPermissions = []string{
+	PermUsersRead,
+	PermUsersActivate,
+	PermUsersDeactivate,
+	PermUsersSuspend,
+	PermUsersBulkUpdate,
+	PermUsersChangeRole,
+	PermUsersDelete,
+	PermUsersRestore,
+	PermUsersErase,
+	PermUsersExport,
+	PermStatsRead,
+	PermAuditRead,
+	PermActivityRead,
+	PermSessionsRead,
+	PermSessionsRevoke,
+	PermAPIKeysManage,
+	PermApprovalsReview,
+	PermDLQManage,
+	PermAll,
+}
+
+// IsKnownPermission checks if the permission is defined by the service
+func IsKnownPermission(permission string) bool {
+	for _, known := range knownPermissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantsPermission checks if the granted permission list covers the permission
+func GrantsPermission(granted []string, permission string) bool {
+	for _, p := range granted {
+		if p == PermAll || p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"handyhub-admin-svc/src/clients"
 	"handyhub-admin-svc/src/internal/dependency"
 	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/rbac"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -84,13 +85,22 @@ func setupPublicRoutes(router *gin.Engine, deps *dependency.Manager) {
 
 func setupAdminRoutes(router *gin.Engine, deps *dependency.Manager) {
 	// Create auth middleware with AuthClient instead of SessionRepo
+	tokenVerifier, err := middleware.NewTokenVerifier(&deps.Config.Security)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to initialize JWT verifier")
+	}
+
 	authMiddleware := middleware.NewAuthMiddleware(
-		deps.Config.Security.JwtKey,
+		tokenVerifier,
 		deps.CacheService,
 		deps.AuthClient,
+		deps.ActivityPublisher,
+		deps.Authorizer,
+		deps.APIKeyService,
 	)
 
 	handler := deps.UserHandler
+	stepUpMaxAge := time.Duration(deps.Config.Security.StepUp.MaxAgeSeconds) * time.Second
 
 	// Apply route name FIRST, then auth middlewares
 	admin := router.Group("/api/v1/admin")
@@ -98,32 +108,225 @@ func setupAdminRoutes(router *gin.Engine, deps *dependency.Manager) {
 		admin.GET("/users",
 			setRouteName("getUsersList"),
 			authMiddleware.RequireAuth(),
-			authMiddleware.RequireAdminRights(),
+			authMiddleware.RequirePermission(rbac.PermUsersRead),
 			handler.GetAllUsers)
 
 		admin.GET("/users/stats",
 			setRouteName("getUsersStats"),
 			authMiddleware.RequireAuth(),
-			authMiddleware.RequireAdminRights(),
+			authMiddleware.RequirePermission(rbac.PermStatsRead),
 			handler.GetUserStats)
 
+		admin.GET("/users/stats/timeseries",
+			setRouteName("getUsersStatsTimeSeries"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermStatsRead),
+			handler.GetUserStatsTimeSeries)
+
+		admin.POST("/users/bulk-status",
+			setRouteName("bulkUpdateUserStatus"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersBulkUpdate),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
+			handler.BulkUpdateStatus)
+
+		admin.GET("/users/bulk-status/:jobId",
+			setRouteName("getBulkStatusJob"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersBulkUpdate),
+			handler.GetBulkStatusJob)
+
+		admin.GET("/users/:id",
+			setRouteName("getUserDetails"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersRead),
+			handler.GetUserByID)
+
 		admin.PATCH("/users/:id/activate",
 			setRouteName("activateUser"),
 			authMiddleware.RequireAuth(),
-			authMiddleware.RequireAdminRights(),
+			authMiddleware.RequirePermission(rbac.PermUsersActivate),
 			handler.ActivateUser)
 
 		admin.PATCH("/users/:id/deactivate",
 			setRouteName("deactivateUser"),
 			authMiddleware.RequireAuth(),
-			authMiddleware.RequireAdminRights(),
+			authMiddleware.RequirePermission(rbac.PermUsersDeactivate),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
 			handler.DeactivateUser)
 
 		admin.PATCH("/users/:id/suspend",
 			setRouteName("suspendUser"),
 			authMiddleware.RequireAuth(),
-			authMiddleware.RequireAdminRights(),
+			authMiddleware.RequirePermission(rbac.PermUsersSuspend),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
 			handler.SuspendUser)
+
+		admin.PATCH("/users/:id/role",
+			setRouteName("changeUserRole"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersChangeRole),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
+			handler.ChangeUserRole)
+
+		admin.DELETE("/users/:id",
+			setRouteName("deleteUser"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersDelete),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
+			handler.DeleteUser)
+
+		admin.POST("/users/:id/restore",
+			setRouteName("restoreUser"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersRestore),
+			handler.RestoreUser)
+
+		admin.POST("/users/:id/erasure",
+			setRouteName("requestUserErasure"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersErase),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
+			deps.ErasureHandler.RequestErasure)
+
+		admin.POST("/users/:id/export",
+			setRouteName("requestUserExport"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersExport),
+			deps.ExportHandler.RequestExport)
+
+		admin.GET("/users/:id/sessions",
+			setRouteName("getUserSessions"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermSessionsRead),
+			deps.SessionHandler.GetUserSessions)
+
+		admin.DELETE("/users/:id/sessions/:sessionId",
+			setRouteName("terminateUserSession"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermSessionsRevoke),
+			deps.SessionHandler.TerminateSession)
+
+		admin.DELETE("/users/:id/sessions",
+			setRouteName("terminateAllUserSessions"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermSessionsRevoke),
+			deps.SessionHandler.TerminateAllSessions)
+
+		admin.GET("/users/:id/activity",
+			setRouteName("getUserActivity"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermActivityRead),
+			deps.ActivityHandler.GetUserActivity)
+
+		admin.GET("/audit",
+			setRouteName("getAuditLog"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermAuditRead),
+			deps.AuditHandler.GetAuditEntries)
+
+		admin.GET("/api-keys",
+			setRouteName("getAPIKeys"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermAPIKeysManage),
+			deps.APIKeyHandler.GetAPIKeys)
+
+		admin.POST("/api-keys",
+			setRouteName("createAPIKey"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermAPIKeysManage),
+			deps.APIKeyHandler.CreateAPIKey)
+
+		admin.DELETE("/api-keys/:id",
+			setRouteName("revokeAPIKey"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermAPIKeysManage),
+			deps.APIKeyHandler.RevokeAPIKey)
+
+		admin.GET("/approvals",
+			setRouteName("getApprovals"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermApprovalsReview),
+			deps.ApprovalHandler.GetApprovals)
+
+		admin.GET("/approvals/:id",
+			setRouteName("getApproval"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermApprovalsReview),
+			deps.ApprovalHandler.GetApproval)
+
+		admin.POST("/approvals/:id/approve",
+			setRouteName("approveAction"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermApprovalsReview),
+			authMiddleware.RequireStepUp(stepUpMaxAge),
+			deps.ApprovalHandler.ApproveAction)
+
+		admin.POST("/approvals/:id/reject",
+			setRouteName("rejectAction"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermApprovalsReview),
+			deps.ApprovalHandler.RejectAction)
+
+		admin.GET("/erasure-requests/:id",
+			setRouteName("getErasureRequest"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersErase),
+			deps.ErasureHandler.GetErasureRequest)
+
+		admin.POST("/erasure-requests/:id/cancel",
+			setRouteName("cancelErasureRequest"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersErase),
+			deps.ErasureHandler.CancelErasure)
+
+		admin.GET("/erasure-requests/:id/certificate",
+			setRouteName("getErasureCertificate"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersErase),
+			deps.ErasureHandler.GetCertificate)
+
+		admin.GET("/exports/:id",
+			setRouteName("getUserExport"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersExport),
+			deps.ExportHandler.GetExport)
+
+		admin.GET("/exports/:id/download",
+			setRouteName("downloadUserExport"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermUsersExport),
+			deps.ExportHandler.DownloadExport)
+
+		admin.GET("/dlq",
+			setRouteName("getDeadLetters"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermDLQManage),
+			deps.DeadLetterHandler.GetDeadLetters)
+
+		admin.DELETE("/dlq",
+			setRouteName("purgeDeadLetters"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermDLQManage),
+			deps.DeadLetterHandler.PurgeDeadLetters)
+
+		admin.GET("/dlq/:id",
+			setRouteName("getDeadLetter"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermDLQManage),
+			deps.DeadLetterHandler.GetDeadLetter)
+
+		admin.POST("/dlq/:id/replay",
+			setRouteName("replayDeadLetter"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermDLQManage),
+			deps.DeadLetterHandler.ReplayDeadLetter)
+
+		admin.DELETE("/dlq/:id",
+			setRouteName("deleteDeadLetter"),
+			authMiddleware.RequireAuth(),
+			authMiddleware.RequirePermission(rbac.PermDLQManage),
+			deps.DeadLetterHandler.DeleteDeadLetter)
 	}
 }
 
@@ -151,7 +354,7 @@ func isRedisConnected(redisClient *redis.Client, c *gin.Context) bool {
 func enableCORS(c *gin.Context) {
 	c.Header("Access-Control-Allow-Origin", "*")
 	c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-	c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	if c.Request.Method == "OPTIONS" {
 		c.AbortWithStatus(204)
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -24,6 +25,9 @@ type Server struct {
 	mongodb     *clients.MongoDB
 	redisClient *clients.RedisClient
 	rabbitMQ    *clients.RabbitMQ
+	jobs        []dependency.Job
+
+	activityPublisher *clients.ActivityPublisher
 }
 
 func New(cfg *config.Configuration) *Server {
@@ -49,6 +53,8 @@ func (s *Server) Start() error {
 		return err
 	}
 
+	s.startJobs()
+
 	go func() {
 		log.Infof("Auth Service starting on port %s", s.config.Server.Port)
 		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -88,10 +94,6 @@ func (s *Server) initRabbitMQ() error {
 		return err
 	}
 	s.rabbitMQ = rabbitmq
-	if err := rabbitmq.SetupQueue(); err != nil {
-		log.WithError(err).Fatal("Failed to setup RabbitMQ queue")
-		return err
-	}
 	return nil
 }
 
@@ -101,6 +103,8 @@ func (s *Server) setupHTTPServer() error {
 
 	dependencyManager := dependency.NewDependencyManager(router, s.mongodb, s.redisClient, s.rabbitMQ, s.config)
 	SetupRoutes(dependencyManager)
+	s.jobs = dependencyManager.Jobs
+	s.activityPublisher = dependencyManager.ActivityPublisher
 
 	s.httpServer = &http.Server{
 		Addr:         s.config.Server.Port,
@@ -114,6 +118,27 @@ func (s *Server) setupHTTPServer() error {
 	return nil
 }
 
+func (s *Server) startJobs() {
+	s.activityPublisher.Start()
+	for _, job := range s.jobs {
+		job.Start()
+	}
+}
+
+// stopJobs stops the jobs concurrently, each saving its own progress
+func (s *Server) stopJobs() {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.Stop()
+		}()
+	}
+	wg.Wait()
+	log.Info("Background jobs stopped")
+}
+
 func (s *Server) waitForShutdown() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -124,9 +149,21 @@ func (s *Server) waitForShutdown() {
 	s.Shutdown()
 }
 
+// Shutdown stops the server stage by stage, each stage with its own deadline so a slow
+// one does not leave the next without time
 func (s *Server) Shutdown() {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	timeout := time.Duration(s.config.Server.ShutdownTimeout) * time.Second
+
+	stopWithin("background jobs", timeout, s.stopJobs)
+
+	// Stop accepting requests before the activity they produce is drained
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		log.WithError(err).Error("Server forced to shutdown")
+	}
+	cancel()
+
+	stopWithin("activity publisher", timeout, s.activityPublisher.Stop)
 
 	if s.redisClient != nil {
 		if err := s.redisClient.Close(); err != nil {
@@ -145,6 +182,9 @@ func (s *Server) Shutdown() {
 	}
 
 	if s.mongodb != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
 		if err := s.mongodb.Disconnect(ctx); err != nil {
 			log.WithError(err).Error("Error disconnecting MongoDB")
 		} else {
@@ -152,9 +192,21 @@ func (s *Server) Shutdown() {
 		}
 	}
 
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		log.WithError(err).Fatal("Server forced to shutdown")
-	}
-
 	log.Info("Auth service gracefully stopped")
 }
+
+// stopWithin runs stop and waits for it at most timeout; a stage that does not finish in
+// time is left running so the remaining stages still get to close their connections
+func stopWithin(name string, timeout time.Duration, stop func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stop()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.WithField("timeout", timeout).Errorf("Timed out stopping %s", name)
+	}
+}
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler interface {
+	GetUserSessions(c *gin.Context)
+	TerminateSession(c *gin.Context)
+	TerminateAllSessions(c *gin.Context)
+}
+
+type handler struct {
+	config  *config.Configuration
+	service Service
+}
+
+func NewHandler(cfg *config.Configuration, service Service) Handler {
+	return &handler{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (h *handler) GetUserSessions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	userID := c.Param("id")
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "limit": limit,
+	}).Info("GetUserSessions request received")
+
+	sessions, err := h.service.GetUserSessions(ctx, userID, limit)
+	if err != nil {
+		h.handleError(c, userID, err, "Failed to retrieve sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    sessions,
+		"message": "Sessions retrieved successfully",
+	})
+}
+
+func (h *handler) TerminateSession(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	userID := c.Param("id")
+	sessionID := c.Param("sessionId")
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "session_id": sessionID,
+	}).Info("TerminateSession request received")
+
+	if err := h.service.TerminateSession(ctx, userID, sessionID, middleware.ActorFromContext(c)); err != nil {
+		h.handleError(c, userID, err, "Failed to terminate session")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Session terminated successfully",
+	})
+}
+
+func (h *handler) TerminateAllSessions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	userID := c.Param("id")
+	logrus.WithField("user_id", userID).Info("TerminateAllSessions request received")
+
+	revoked, err := h.service.TerminateAllSessions(ctx, userID, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleError(c, userID, err, "Failed to terminate sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"terminated": revoked},
+		"message": "Sessions terminated successfully",
+	})
+}
+
+func (h *handler) handleError(c *gin.Context, userID string, err error, message string) {
+	logrus.WithError(err).WithField("user_id", userID).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
+	case errors.Is(err, models.ErrSessionNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Session not found", "No active session found with the provided ID")
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
+	c.JSON(statusCode, gin.H{
+		"error":   error,
+		"success": false,
+		"message": message,
+	})
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+)
+
+// RevokedMessage is published when sessions of a user are revoked by an admin
+type RevokedMessage struct {
+	UserID     string    `json:"user_id"`
+	SessionIDs []string  `json:"session_ids"`
+	Reason     string    `json:"reason"`
+	RevokedBy  string    `json:"revoked_by"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Details represents a session as shown to admins
+type Details struct {
+	SessionID    string     `json:"sessionId"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastActiveAt time.Time  `json:"lastActiveAt"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	LogoutAt     *time.Time `json:"logoutAt,omitempty"`
+}
+
+// Session status constants
+const (
+	StatusActive    = "active"
+	StatusExpired   = "expired"
+	StatusLoggedOut = "logged_out"
+)
+
+// Revocation reason constants
+const (
+	ReasonUserStatusChanged = "user_status_changed"
+	ReasonAdminTerminated   = "admin_terminated"
+	ReasonUserErased        = "user_erased"
+)
+
+func toDetails(session *models.Session, now time.Time) *Details {
+	status := StatusActive
+	switch {
+	case !session.IsActive || session.LogoutAt != nil:
+		status = StatusLoggedOut
+	case now.After(session.ExpiresAt):
+		status = StatusExpired
+	}
+
+	return &Details{
+		SessionID:    session.SessionID,
+		Status:       status,
+		CreatedAt:    session.CreatedAt,
+		LastActiveAt: session.LastActiveAt,
+		ExpiresAt:    session.ExpiresAt,
+		LogoutAt:     session.LogoutAt,
+	}
+}
+
+func sessionIDs(sessions []*models.Session) []string {
+	ids := make([]string, len(sessions))
+	for i, session := range sessions {
+		ids[i] = session.SessionID
+	}
+	return ids
+}
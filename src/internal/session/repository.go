@@ -0,0 +1,159 @@
+package session
+
+import (
+	"context"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	CountActiveByUser(ctx context.Context, userID string) (int64, error)
+	FindActiveByUser(ctx context.Context, userID string) ([]*models.Session, error)
+	DeactivateAllByUser(ctx context.Context, userID string) (int64, error)
+	FindByUser(ctx context.Context, userID string, limit int) ([]*models.Session, error)
+	DeactivateByID(ctx context.Context, userID, sessionID string) error
+	AnonymizeByUser(ctx context.Context, userID string) (int64, error)
+}
+
+type sessionRepository struct {
+	Collection mongo.Collection
+}
+
+func NewSessionRepository(mongoClient *clients.MongoDB, collectionName string) Repository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &sessionRepository{
+		Collection: collection,
+	}
+}
+
+func (r *sessionRepository) CountActiveByUser(ctx context.Context, userID string) (int64, error) {
+	filter := activeSessionsFilter(userID)
+
+	count, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to count active sessions")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *sessionRepository) FindActiveByUser(ctx context.Context, userID string) ([]*models.Session, error) {
+	cursor, err := r.Collection.Find(ctx, activeSessionsFilter(userID))
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to find active sessions")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []*models.Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to decode active sessions")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// DeactivateAllByUser marks every active session of the user as logged out
+func (r *sessionRepository) DeactivateAllByUser(ctx context.Context, userID string) (int64, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"is_active": false,
+			"logout_at": time.Now(),
+		},
+	}
+
+	result, err := r.Collection.UpdateMany(ctx, activeSessionsFilter(userID), update)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to deactivate user sessions")
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// FindByUser returns the most recent sessions of the user regardless of their state.
+// A zero limit returns all of them.
+func (r *sessionRepository) FindByUser(ctx context.Context, userID string, limit int) ([]*models.Session, error) {
+	opts := options.Find().
+		SetSort(bson.M{"created_at": -1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.Collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to find user sessions")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := make([]*models.Session, 0, limit)
+	if err := cursor.All(ctx, &sessions); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to decode user sessions")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// DeactivateByID marks a single active session of the user as logged out
+func (r *sessionRepository) DeactivateByID(ctx context.Context, userID, sessionID string) error {
+	filter := activeSessionsFilter(userID)
+	filter["session_id"] = sessionID
+
+	update := bson.M{
+		"$set": bson.M{
+			"is_active": false,
+			"logout_at": time.Now(),
+		},
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID, "session_id": sessionID,
+		}).Error("Failed to deactivate session")
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// activeSessionsFilter matches sessions that are still usable for the given user
+func activeSessionsFilter(userID string) bson.M {
+	return bson.M{
+		"user_id":    userID,
+		"is_active":  true,
+		"logout_at":  nil,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+}
+
+// AnonymizeByUser removes the network details the auth service records with each session
+func (r *sessionRepository) AnonymizeByUser(ctx context.Context, userID string) (int64, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"$or": []bson.M{
+			{"ip_address": bson.M{"$exists": true}},
+			{"user_agent": bson.M{"$exists": true}},
+		},
+	}
+	update := bson.M{"$unset": bson.M{"ip_address": "", "user_agent": ""}}
+
+	result, err := r.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to anonymize user sessions")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,212 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/cache"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Service interface {
+	CountActiveSessions(ctx context.Context, userID string) (int64, error)
+	GetUserSessions(ctx context.Context, userID string, limit int) ([]*Details, error)
+	GetAllUserSessions(ctx context.Context, userID string) ([]*Details, error)
+	RevokeAllForUser(ctx context.Context, userID, reason string, actor *models.Actor) (int64, error)
+	TerminateSession(ctx context.Context, userID, sessionID string, actor *models.Actor) error
+	TerminateAllSessions(ctx context.Context, userID string, actor *models.Actor) (int64, error)
+	AnonymizeUserSessions(ctx context.Context, userID string) (int64, error)
+}
+
+type sessionService struct {
+	sessionRepository Repository
+	cacheService      cache.Service
+	auditService      audit.Service
+	authClient        *clients.AuthClient
+	cfg               *config.Configuration
+}
+
+func NewSessionService(sessionRepository Repository,
+	cacheService cache.Service,
+	auditService audit.Service,
+	authClient *clients.AuthClient,
+	cfg *config.Configuration) Service {
+	return &sessionService{
+		sessionRepository: sessionRepository,
+		cacheService:      cacheService,
+		auditService:      auditService,
+		authClient:        authClient,
+		cfg:               cfg,
+	}
+}
+
+func (s *sessionService) CountActiveSessions(ctx context.Context, userID string) (int64, error) {
+	return s.sessionRepository.CountActiveByUser(ctx, userID)
+}
+
+// GetUserSessions returns active and recent sessions of the user, newest first
+func (s *sessionService) GetUserSessions(ctx context.Context, userID string, limit int) ([]*Details, error) {
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = s.cfg.Search.MinQueryLimit
+	}
+	if limit > s.cfg.Search.MaxQueryLimit {
+		limit = s.cfg.Search.MaxQueryLimit
+	}
+
+	return s.findUserSessions(ctx, userID, limit)
+}
+
+// GetAllUserSessions returns the whole session history of the user, newest first
+func (s *sessionService) GetAllUserSessions(ctx context.Context, userID string) ([]*Details, error) {
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	return s.findUserSessions(ctx, userID, 0)
+}
+
+func (s *sessionService) findUserSessions(ctx context.Context, userID string, limit int) ([]*Details, error) {
+	sessions, err := s.sessionRepository.FindByUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	details := make([]*Details, len(sessions))
+	for i, session := range sessions {
+		details[i] = toDetails(session, now)
+	}
+
+	return details, nil
+}
+
+// RevokeAllForUser logs the user out everywhere: session documents are deactivated,
+// cached sessions are dropped and a revocation event is published for other services
+func (s *sessionService) RevokeAllForUser(ctx context.Context, userID, reason string, actor *models.Actor) (int64, error) {
+	sessions, err := s.sessionRepository.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := s.sessionRepository.DeactivateAllByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := s.cacheService.DeleteUserSessions(ctx, userID); err != nil {
+		return revoked, err
+	}
+
+	if err := s.publishRevoked(userID, sessionIDs(sessions), reason, actor); err != nil {
+		return revoked, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "revoked": revoked, "reason": reason, "actor_id": actor.ID,
+	}).Info("User sessions revoked")
+
+	return revoked, nil
+}
+
+// TerminateSession force logs out a single session of the user
+func (s *sessionService) TerminateSession(ctx context.Context, userID, sessionID string, actor *models.Actor) error {
+	if err := validateUserID(userID); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepository.DeactivateByID(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrSessionNotFound
+		}
+		return err
+	}
+
+	if err := s.cacheService.DeleteSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	if err := s.publishRevoked(userID, []string{sessionID}, ReasonAdminTerminated, actor); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionTerminateSession,
+		TargetUserID: userID,
+		Metadata:     map[string]string{"session_id": sessionID},
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "session_id": sessionID, "actor_id": actor.ID,
+	}).Info("User session terminated")
+
+	return nil
+}
+
+// TerminateAllSessions force logs out every session of the user
+func (s *sessionService) TerminateAllSessions(ctx context.Context, userID string, actor *models.Actor) (int64, error) {
+	if err := validateUserID(userID); err != nil {
+		return 0, err
+	}
+
+	revoked, err := s.RevokeAllForUser(ctx, userID, ReasonAdminTerminated, actor)
+	if err != nil {
+		return revoked, err
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionTerminateAllSessions,
+		TargetUserID: userID,
+		Metadata:     map[string]string{"revoked": strconv.FormatInt(revoked, 10)},
+	})
+
+	return revoked, nil
+}
+
+// AnonymizeUserSessions removes IP addresses and user agents from the sessions of an erased user
+func (s *sessionService) AnonymizeUserSessions(ctx context.Context, userID string) (int64, error) {
+	return s.sessionRepository.AnonymizeByUser(ctx, userID)
+}
+
+func (s *sessionService) publishRevoked(userID string, sessionIDs []string, reason string, actor *models.Actor) error {
+	message := &RevokedMessage{
+		UserID:     userID,
+		SessionIDs: sessionIDs,
+		Reason:     reason,
+		RevokedBy:  actor.ID,
+		Timestamp:  time.Now(),
+	}
+
+	return s.authClient.PublishEvent(s.cfg.Messaging.Queues.SessionsRevoked.RoutingKey, message)
+}
+
+// recordAudit writes an audit entry; sessions are already revoked at this point,
+// so a failure here is logged rather than returned to the caller
+func (s *sessionService) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":         entry.Action,
+			"target_user_id": entry.TargetUserID,
+		}).Error("Failed to record audit entry")
+	}
+}
+
+func validateUserID(userID string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return models.ErrInvalidParams
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package user
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/approval"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// approvableActions maps status actions that may be held for approval to their target status
+var approvableActions = map[string]string{
+	audit.ActionDeactivateUser: StatusInactive,
+	audit.ActionSuspendUser:    StatusSuspended,
+}
+
+// requestApproval queues the change for a second admin instead of applying it
+func (s *userService) requestApproval(ctx context.Context, current *User, action string,
+	params map[string]string, actor *models.Actor) error {
+	pending, err := s.approvalService.Request(ctx, &approval.PendingAction{
+		Action:       action,
+		TargetUserID: current.ID.Hex(),
+		TargetEmail:  current.Email,
+		TargetRole:   current.Role,
+		Params:       params,
+		RequestedBy:  *actor,
+	})
+	if err != nil {
+		return err
+	}
+
+	return &models.ApprovalRequiredError{ApprovalID: pending.ID.Hex()}
+}
+
+// ExecuteApproved applies a change approved by a second admin. The change is attributed
+// to the requester and re-validated, since the user may have changed meanwhile.
+func (s *userService) ExecuteApproved(ctx context.Context, action *approval.PendingAction, reviewer *models.Actor) error {
+	switch action.Action {
+	case audit.ActionDeleteUser:
+		return s.executeApprovedDelete(ctx, action, reviewer)
+	case audit.ActionBulkStatus:
+		return s.executeApprovedBulk(ctx, action, reviewer)
+	}
+
+	status, ok := approvableActions[action.Action]
+	if !ok {
+		return models.ErrApprovalUnsupported
+	}
+
+	requester := &action.RequestedBy
+
+	var suspension *Suspension
+	if status == StatusSuspended {
+		var err error
+		if suspension, err = suspensionFromParams(action.Params, requester); err != nil {
+			return err
+		}
+	}
+
+	current, err := s.getForStatusUpdate(ctx, action.TargetUserID, status, requester)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"approval_id": action.ID.Hex(), "user_id": action.TargetUserID, "reviewer_id": reviewer.ID,
+	}).Info("Executing approved status change")
+
+	return s.applyStatusChange(ctx, current, status, action.Action, suspension, requester, approvalMetadata(action, reviewer))
+}
+
+func (s *userService) executeApprovedDelete(ctx context.Context, action *approval.PendingAction, reviewer *models.Actor) error {
+	requester := &action.RequestedBy
+
+	current, err := s.getForDelete(ctx, action.TargetUserID, requester)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"approval_id": action.ID.Hex(), "user_id": action.TargetUserID, "reviewer_id": reviewer.ID,
+	}).Info("Executing approved user deletion")
+
+	return s.applyDelete(ctx, current, action.Params["reason"], requester, approvalMetadata(action, reviewer))
+}
+
+// executeApprovedBulk queues the bulk job held for the approval; the bulk status job runs it
+func (s *userService) executeApprovedBulk(ctx context.Context, action *approval.PendingAction, reviewer *models.Actor) error {
+	jobID, err := primitive.ObjectIDFromHex(action.Params["job_id"])
+	if err != nil {
+		return models.ErrInvalidParams
+	}
+
+	if err := s.bulkJobRepository.Approve(ctx, jobID, action.ID.Hex(), reviewer.ID); err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"approval_id": action.ID.Hex(), "job_id": jobID.Hex(), "reviewer_id": reviewer.ID,
+	}).Info("Approved bulk status job queued")
+
+	return nil
+}
+
+func approvalMetadata(action *approval.PendingAction, reviewer *models.Actor) map[string]string {
+	return map[string]string{
+		"approval_id": action.ID.Hex(),
+		"approved_by": reviewer.ID,
+	}
+}
+
+// suspensionFromParams restores suspension details stored by suspensionMetadata
+func suspensionFromParams(params map[string]string, requester *models.Actor) (*Suspension, error) {
+	if params["reason"] == "" {
+		return nil, models.ErrSuspensionReasonRequired
+	}
+
+	now := time.Now()
+	suspension := &Suspension{
+		Reason:      params["reason"],
+		Note:        params["note"],
+		SuspendedAt: now,
+		SuspendedBy: requester.ID,
+	}
+
+	if until, ok := params["until"]; ok {
+		parsed, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, models.ErrInvalidParams
+		}
+		if !parsed.After(now) {
+			return nil, models.ErrInvalidSuspensionEnd
+		}
+		suspension.Until = &parsed
+	}
+
+	return suspension, nil
+}
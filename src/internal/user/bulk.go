@@ -0,0 +1,374 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/approval"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bulkStatusActions maps target statuses of bulk updates to their audit actions
+var bulkStatusActions = map[string]string{
+	StatusActive:    audit.ActionActivateUser,
+	StatusInactive:  audit.ActionDeactivateUser,
+	StatusSuspended: audit.ActionSuspendUser,
+}
+
+// BulkUpdateStatus changes the status of users selected by IDs or filter. Dry runs only
+// count affected users; selections above the async threshold are queued as a job, as are
+// the users left when a synchronous run outlives the request. If any selected user needs
+// approval, the whole update is held as one job until a second admin approves it.
+func (s *userService) BulkUpdateStatus(ctx context.Context, req *BulkStatusRequest, actor *models.Actor) (*BulkStatusResponse, error) {
+	if err := validateBulkRequest(req); err != nil {
+		return nil, err
+	}
+
+	ids, affected, err := s.resolveBulkTargets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		return &BulkStatusResponse{DryRun: true, Affected: affected}, nil
+	}
+
+	job := &BulkJob{
+		Status:     BulkJobQueued,
+		UserStatus: req.Status,
+		UserIDs:    ids,
+		Reason:     strings.TrimSpace(req.Reason),
+		Note:       strings.TrimSpace(req.Note),
+		Until:      req.Until,
+		Actor:      *actor,
+		Summary:    BulkSummary{Total: len(ids)},
+		Results:    make([]*BulkResult, 0, len(ids)),
+		CreatedAt:  time.Now(),
+	}
+
+	approvalRequired, err := s.bulkRequiresApproval(ctx, ids, req.Status, actor)
+	if err != nil {
+		return nil, err
+	}
+	if approvalRequired {
+		return s.requestBulkApproval(ctx, job, affected)
+	}
+
+	if len(ids) > s.cfg.Jobs.BulkStatus.AsyncThreshold {
+		if err := s.bulkJobRepository.Insert(ctx, job); err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID.Hex(), "users": len(ids), "status": req.Status, "actor_id": actor.ID,
+		}).Info("Bulk status job queued")
+
+		return &BulkStatusResponse{Affected: affected, JobID: job.ID.Hex()}, nil
+	}
+
+	s.runBulkJob(ctx, job)
+	if len(job.Results) < len(job.UserIDs) {
+		s.queueRemainder(job)
+	}
+
+	response := &BulkStatusResponse{Affected: affected, Summary: &job.Summary, Results: job.Results}
+	if !job.ID.IsZero() {
+		response.JobID = job.ID.Hex()
+	}
+	return response, nil
+}
+
+// queueRemainder stores a synchronous run interrupted by the request deadline as a job,
+// so the background job processes the users not handled yet. If the job cannot be
+// stored, those users are reported as failed instead.
+func (s *userService) queueRemainder(job *BulkJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.cfg.App.Timeout)*time.Second)
+	defer cancel()
+
+	if err := s.bulkJobRepository.Insert(ctx, job); err == nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID.Hex(), "processed": job.Summary.Processed, "remaining": len(job.UserIDs) - len(job.Results),
+		}).Warn("Bulk status update interrupted, remaining users queued")
+		return
+	}
+
+	for _, id := range job.UserIDs[len(job.Results):] {
+		result := &BulkResult{UserID: id, Error: models.ErrBulkNotProcessed.Error()}
+		result.apply(&job.Summary)
+		job.Results = append(job.Results, result)
+	}
+}
+
+// bulkRequiresApproval applies the approval policy to every role among the selected users
+func (s *userService) bulkRequiresApproval(ctx context.Context, ids []string, status string, actor *models.Actor) (bool, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+
+	roles, err := s.userRepository.FindRolesByIDs(ctx, objectIDs)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if s.approvalService.RequiresApproval(bulkStatusActions[status], role, actor) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// requestBulkApproval stores the job without queueing it and requests one approval for
+// the whole update, in one transaction so no job is left without its approval request.
+// The job is queued once the request is approved; after a rejection or expiry it stays
+// waiting and never runs.
+func (s *userService) requestBulkApproval(ctx context.Context, job *BulkJob, affected int64) (*BulkStatusResponse, error) {
+	job.Status = BulkJobAwaitingApproval
+	if job.ID.IsZero() {
+		job.ID = primitive.NewObjectID()
+	}
+
+	params := map[string]string{
+		"job_id": job.ID.Hex(),
+		"status": job.UserStatus,
+		"users":  strconv.Itoa(len(job.UserIDs)),
+	}
+	if job.Reason != "" {
+		params["reason"] = job.Reason
+	}
+	if job.Until != nil {
+		params["until"] = job.Until.Format(time.RFC3339)
+	}
+
+	var pending *approval.PendingAction
+	err := s.outboxService.RunInTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.bulkJobRepository.Insert(txCtx, job); err != nil {
+			return err
+		}
+
+		var err error
+		pending, err = s.approvalService.Request(txCtx, &approval.PendingAction{
+			Action:      audit.ActionBulkStatus,
+			Params:      params,
+			RequestedBy: job.Actor,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID.Hex(), "approval_id": pending.ID.Hex(), "users": len(job.UserIDs), "actor_id": job.Actor.ID,
+	}).Info("Bulk status job is waiting for approval")
+
+	return &BulkStatusResponse{Affected: affected, JobID: job.ID.Hex(), ApprovalID: pending.ID.Hex()}, nil
+}
+
+func (s *userService) GetBulkJob(ctx context.Context, id string) (*BulkJob, error) {
+	jobID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+	return s.bulkJobRepository.GetByID(ctx, jobID)
+}
+
+// RunNextBulkJob claims and processes the oldest queued job. It reports whether a job
+// was found. A job interrupted by ctx is saved and queued again to resume later.
+func (s *userService) RunNextBulkJob(ctx context.Context) (bool, error) {
+	now := time.Now()
+	lease := time.Duration(s.cfg.Jobs.BulkStatus.LeaseMinutes) * time.Minute
+	job, err := s.bulkJobRepository.ClaimNext(ctx, now, now.Add(-lease))
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID.Hex(), "total": job.Summary.Total, "processed": job.Summary.Processed,
+	}).Info("Bulk status job started")
+
+	s.runBulkJob(ctx, job)
+
+	// Persist with a fresh context, ctx may already be cancelled by shutdown
+	saveCtx, cancel := context.WithTimeout(context.Background(), time.Duration(s.cfg.App.Timeout)*time.Second)
+	defer cancel()
+
+	if ctx.Err() != nil {
+		logrus.WithField("job_id", job.ID.Hex()).Warn("Bulk status job interrupted, requeueing")
+		return true, s.bulkJobRepository.Requeue(saveCtx, job.ID, job.Summary, job.Results)
+	}
+
+	if err := s.bulkJobRepository.Complete(saveCtx, job.ID, job.Summary, job.Results, time.Now()); err != nil {
+		return true, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID.Hex(), "succeeded": job.Summary.Succeeded,
+		"failed": job.Summary.Failed, "pending_approval": job.Summary.PendingApproval,
+	}).Info("Bulk status job completed")
+
+	return true, nil
+}
+
+// runBulkJob processes users not handled yet, saving progress of stored jobs batch by batch
+func (s *userService) runBulkJob(ctx context.Context, job *BulkJob) {
+	batchSize := s.cfg.Jobs.BulkStatus.BatchSize
+
+	for _, id := range job.UserIDs[len(job.Results):] {
+		if ctx.Err() != nil {
+			return
+		}
+
+		result := s.bulkUpdateUser(ctx, job, id)
+		result.apply(&job.Summary)
+		job.Results = append(job.Results, result)
+
+		if !job.ID.IsZero() && batchSize > 0 && job.Summary.Processed%batchSize == 0 {
+			_ = s.bulkJobRepository.SaveProgress(ctx, job.ID, job.Summary, job.Results)
+		}
+	}
+}
+
+func (s *userService) bulkUpdateUser(ctx context.Context, job *BulkJob, id string) *BulkResult {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.App.Timeout)*time.Second)
+	defer cancel()
+
+	var suspension *Suspension
+	if job.UserStatus == StatusSuspended {
+		suspension = &Suspension{
+			Reason:      job.Reason,
+			Note:        job.Note,
+			Until:       job.Until,
+			SuspendedAt: time.Now(),
+			SuspendedBy: job.Actor.ID,
+		}
+	}
+
+	result := &BulkResult{UserID: id}
+	var err error
+	if job.ApprovalID != "" {
+		err = s.applyApprovedBulkUser(ctx, job, id, suspension)
+	} else {
+		err = s.updateUserStatus(ctx, id, job.UserStatus, bulkStatusActions[job.UserStatus], suspension, &job.Actor)
+	}
+
+	var approvalErr *models.ApprovalRequiredError
+	switch {
+	case err == nil:
+		result.Success = true
+	case errors.As(err, &approvalErr):
+		result.ApprovalID = approvalErr.ApprovalID
+		result.Error = err.Error()
+	default:
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// applyApprovedBulkUser changes the status of one user of an approved job without asking
+// for approval again; the transition is still validated
+func (s *userService) applyApprovedBulkUser(ctx context.Context, job *BulkJob, id string, suspension *Suspension) error {
+	current, err := s.getForStatusUpdate(ctx, id, job.UserStatus, &job.Actor)
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]string{
+		"approval_id": job.ApprovalID,
+		"approved_by": job.ApprovedBy,
+		"bulk_job_id": job.ID.Hex(),
+	}
+	return s.applyStatusChange(ctx, current, job.UserStatus, bulkStatusActions[job.UserStatus], suspension, &job.Actor, metadata)
+}
+
+// resolveBulkTargets returns the user IDs to process and how many existing users they cover
+func (s *userService) resolveBulkTargets(ctx context.Context, req *BulkStatusRequest) ([]string, int64, error) {
+	maxUsers := s.cfg.Jobs.BulkStatus.MaxUsers
+
+	if len(req.UserIDs) > 0 {
+		ids := uniqueIDs(req.UserIDs)
+		if len(ids) > maxUsers {
+			return nil, 0, models.ErrBulkTooManyUsers
+		}
+
+		objectIDs := make([]primitive.ObjectID, 0, len(ids))
+		for _, id := range ids {
+			// Invalid IDs are kept and reported as per-user failures
+			if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+				objectIDs = append(objectIDs, objectID)
+			}
+		}
+
+		affected, err := s.userRepository.CountByIDs(ctx, objectIDs)
+		if err != nil {
+			return nil, 0, err
+		}
+		return ids, affected, nil
+	}
+
+	ids, err := s.userRepository.FindIDsByFilter(ctx, req.Filter, maxUsers+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(ids) > maxUsers {
+		return nil, 0, models.ErrBulkTooManyUsers
+	}
+	return ids, int64(len(ids)), nil
+}
+
+// validateBulkRequest requires exactly one of IDs or a non-empty filter. Unlike the list
+// endpoint, invalid filter values are rejected, since dropping them widens the selection.
+func validateBulkRequest(req *BulkStatusRequest) error {
+	if _, ok := bulkStatusActions[req.Status]; !ok {
+		return models.ErrInvalidUserStatus
+	}
+
+	hasFilter := req.Filter != nil && (req.Filter.Role != "" || req.Filter.Status != "" || req.Filter.Search != "")
+	if (len(req.UserIDs) > 0) == hasFilter {
+		return models.ErrBulkTargetsRequired
+	}
+
+	if hasFilter {
+		if req.Filter.Role != "" && !isValidRole(req.Filter.Role) {
+			return models.ErrInvalidRole
+		}
+		if req.Filter.Status != "" && !isValidStatus(req.Filter.Status) {
+			return models.ErrInvalidUserStatus
+		}
+	}
+
+	if req.Status == StatusSuspended {
+		if strings.TrimSpace(req.Reason) == "" {
+			return models.ErrSuspensionReasonRequired
+		}
+		if req.Until != nil && !req.Until.After(time.Now()) {
+			return models.ErrInvalidSuspensionEnd
+		}
+	}
+
+	return nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BulkStatusJob processes queued bulk status jobs in the background
+type BulkStatusJob struct {
+	service  Service
+	cfg      *config.BulkStatusJobConfig
+	ctx      context.Context
+	cancel   context.CancelFunc
+	finished chan struct{}
+}
+
+func NewBulkStatusJob(service Service, cfg *config.Configuration) *BulkStatusJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BulkStatusJob{
+		service:  service,
+		cfg:      &cfg.Jobs.BulkStatus,
+		ctx:      ctx,
+		cancel:   cancel,
+		finished: make(chan struct{}),
+	}
+}
+
+// Start runs the job in the background until Stop is called
+func (j *BulkStatusJob) Start() {
+	if !j.cfg.Enabled {
+		logrus.Info("Bulk status job is disabled")
+		close(j.finished)
+		return
+	}
+
+	interval := time.Duration(j.cfg.IntervalSeconds) * time.Second
+	logrus.WithField("interval", interval).Info("Starting bulk status job")
+
+	go func() {
+		defer close(j.finished)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				j.run()
+			case <-j.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop interrupts the running bulk job, which is requeued, and waits for it to be saved
+func (j *BulkStatusJob) Stop() {
+	j.cancel()
+	<-j.finished
+	logrus.Info("Bulk status job stopped")
+}
+
+// run drains the queue so that jobs submitted together do not wait an interval each
+func (j *BulkStatusJob) run() {
+	for j.ctx.Err() == nil {
+		found, err := j.service.RunNextBulkJob(j.ctx)
+		if err != nil {
+			logrus.WithError(err).Error("Bulk status job failed")
+			return
+		}
+		if !found {
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bulk job status constants
+const (
+	BulkJobAwaitingApproval = "awaiting_approval"
+	BulkJobQueued           = "queued"
+	BulkJobRunning          = "running"
+	BulkJobCompleted        = "completed"
+)
+
+// BulkFilter selects users the same way as the user list endpoint
+type BulkFilter struct {
+	Role   string `json:"role" bson:"role,omitempty"`
+	Status string `json:"status" bson:"status,omitempty"`
+	Search string `json:"search" bson:"search,omitempty"`
+}
+
+// BulkStatusRequest represents request body for changing the status of many users
+type BulkStatusRequest struct {
+	Status  string      `json:"status" binding:"required"`
+	UserIDs []string    `json:"userIds"`
+	Filter  *BulkFilter `json:"filter"`
+	Reason  string      `json:"reason"`
+	Note    string      `json:"note"`
+	Until   *time.Time  `json:"until"`
+	DryRun  bool        `json:"dryRun"`
+}
+
+// BulkResult is the outcome of the status change for a single user
+type BulkResult struct {
+	UserID     string `json:"userId" bson:"user_id"`
+	Success    bool   `json:"success" bson:"success"`
+	Error      string `json:"error,omitempty" bson:"error,omitempty"`
+	ApprovalID string `json:"approvalId,omitempty" bson:"approval_id,omitempty"`
+}
+
+// BulkSummary counts processed users by outcome
+type BulkSummary struct {
+	Total           int `json:"total" bson:"total"`
+	Processed       int `json:"processed" bson:"processed"`
+	Succeeded       int `json:"succeeded" bson:"succeeded"`
+	Failed          int `json:"failed" bson:"failed"`
+	PendingApproval int `json:"pendingApproval" bson:"pending_approval"`
+}
+
+// BulkStatusResponse is returned for dry runs, synchronous runs, queued jobs and jobs
+// waiting for approval
+type BulkStatusResponse struct {
+	DryRun     bool          `json:"dryRun"`
+	Affected   int64         `json:"affected"`
+	JobID      string        `json:"jobId,omitempty"`
+	ApprovalID string        `json:"approvalId,omitempty"`
+	Summary    *BulkSummary  `json:"summary,omitempty"`
+	Results    []*BulkResult `json:"results,omitempty"`
+}
+
+// BulkJob is a large bulk status update processed in the background
+type BulkJob struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Status      string             `json:"status" bson:"status"`
+	UserStatus  string             `json:"userStatus" bson:"user_status"`
+	UserIDs     []string           `json:"-" bson:"user_ids"`
+	Reason      string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	Note        string             `json:"note,omitempty" bson:"note,omitempty"`
+	Until       *time.Time         `json:"until,omitempty" bson:"until,omitempty"`
+	Actor       models.Actor       `json:"actor" bson:"actor"`
+	ApprovalID  string             `json:"approvalId,omitempty" bson:"approval_id,omitempty"`
+	ApprovedBy  string             `json:"approvedBy,omitempty" bson:"approved_by,omitempty"`
+	Summary     BulkSummary        `json:"summary" bson:"summary"`
+	Results     []*BulkResult      `json:"results" bson:"results"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+	StartedAt   *time.Time         `json:"startedAt,omitempty" bson:"started_at,omitempty"`
+	HeartbeatAt *time.Time         `json:"heartbeatAt,omitempty" bson:"heartbeat_at,omitempty"`
+	FinishedAt  *time.Time         `json:"finishedAt,omitempty" bson:"finished_at,omitempty"`
+}
+
+func (r *BulkResult) apply(summary *BulkSummary) {
+	summary.Processed++
+	switch {
+	case r.Success:
+		summary.Succeeded++
+	case r.ApprovalID != "":
+		summary.PendingApproval++
+	default:
+		summary.Failed++
+	}
+}
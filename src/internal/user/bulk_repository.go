@@ -0,0 +1,160 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/clients"
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type BulkJobRepository interface {
+	Insert(ctx context.Context, job *BulkJob) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*BulkJob, error)
+	ClaimNext(ctx context.Context, now, staleBefore time.Time) (*BulkJob, error)
+	SaveProgress(ctx context.Context, id primitive.ObjectID, summary BulkSummary, results []*BulkResult) error
+	Complete(ctx context.Context, id primitive.ObjectID, summary BulkSummary, results []*BulkResult, at time.Time) error
+	Requeue(ctx context.Context, id primitive.ObjectID, summary BulkSummary, results []*BulkResult) error
+	Approve(ctx context.Context, id primitive.ObjectID, approvalID, approvedBy string) error
+	RemoveNotes(ctx context.Context, userID string) (int64, error)
+}
+
+type bulkJobRepository struct {
+	Collection mongo.Collection
+}
+
+func NewBulkJobRepository(mongoClient *clients.MongoDB, collectionName string) BulkJobRepository {
+	collection := *mongoClient.Database.Collection(collectionName)
+	return &bulkJobRepository{
+		Collection: collection,
+	}
+}
+
+func (r *bulkJobRepository) Insert(ctx context.Context, job *BulkJob) error {
+	result, err := r.Collection.InsertOne(ctx, job)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to insert bulk job")
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		job.ID = id
+	}
+	return nil
+}
+
+func (r *bulkJobRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*BulkJob, error) {
+	var job BulkJob
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrBulkJobNotFound
+		}
+		logrus.WithError(err).WithField("job_id", id.Hex()).Error("Failed to get bulk job")
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimNext atomically marks the oldest queued job as running, so each job runs once.
+// Running jobs without a heartbeat since staleBefore, left by a crashed instance, are
+// claimed again and resume after their saved results.
+func (r *bulkJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*BulkJob, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"status": BulkJobQueued},
+		{"status": BulkJobRunning, "heartbeat_at": bson.M{"$lte": staleBefore}},
+	}}
+	update := bson.M{"$set": bson.M{"status": BulkJobRunning, "started_at": now, "heartbeat_at": now}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"created_at": 1}).
+		SetReturnDocument(options.After)
+
+	var job BulkJob
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		logrus.WithError(err).Error("Failed to claim bulk job")
+		return nil, err
+	}
+	return &job, nil
+}
+
+// SaveProgress stores the results so far and renews the lease of the running job
+func (r *bulkJobRepository) SaveProgress(ctx context.Context, id primitive.ObjectID, summary BulkSummary, results []*BulkResult) error {
+	update := bson.M{"$set": bson.M{"summary": summary, "results": results, "heartbeat_at": time.Now()}}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("job_id", id.Hex()).Error("Failed to save bulk job progress")
+		return err
+	}
+	return nil
+}
+
+func (r *bulkJobRepository) Complete(ctx context.Context, id primitive.ObjectID, summary BulkSummary, results []*BulkResult, at time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"status":      BulkJobCompleted,
+		"summary":     summary,
+		"results":     results,
+		"finished_at": at,
+	}}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("job_id", id.Hex()).Error("Failed to complete bulk job")
+		return err
+	}
+	return nil
+}
+
+// Requeue stores the progress of an interrupted job and queues it to resume
+func (r *bulkJobRepository) Requeue(ctx context.Context, id primitive.ObjectID, summary BulkSummary, results []*BulkResult) error {
+	update := bson.M{"$set": bson.M{
+		"status":  BulkJobQueued,
+		"summary": summary,
+		"results": results,
+	}}
+
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		logrus.WithError(err).WithField("job_id", id.Hex()).Error("Failed to requeue bulk job")
+		return err
+	}
+	return nil
+}
+
+// Approve queues a job that was waiting for approval, recording who approved it
+func (r *bulkJobRepository) Approve(ctx context.Context, id primitive.ObjectID, approvalID, approvedBy string) error {
+	filter := bson.M{"_id": id, "status": BulkJobAwaitingApproval}
+	update := bson.M{"$set": bson.M{
+		"status":      BulkJobQueued,
+		"approval_id": approvalID,
+		"approved_by": approvedBy,
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithField("job_id", id.Hex()).Error("Failed to approve bulk job")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrBulkJobNotFound
+	}
+	return nil
+}
+
+// RemoveNotes drops the free-text note of every job that included the user
+func (r *bulkJobRepository) RemoveNotes(ctx context.Context, userID string) (int64, error) {
+	filter := bson.M{"user_ids": userID, "note": bson.M{"$exists": true}}
+	update := bson.M{"$unset": bson.M{"note": ""}}
+
+	result, err := r.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to remove bulk job notes")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
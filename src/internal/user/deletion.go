@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/events"
+	"handyhub-admin-svc/src/internal/models"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteUser soft deletes a user with a mandatory reason and logs the user out
+func (s *userService) DeleteUser(ctx context.Context, id string, req *DeleteUserRequest, actor *models.Actor) error {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return models.ErrDeletionReasonRequired
+	}
+
+	current, err := s.getForDelete(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+
+	if s.approvalService.RequiresApproval(audit.ActionDeleteUser, current.Role, actor) {
+		return s.requestApproval(ctx, current, audit.ActionDeleteUser, map[string]string{"reason": reason}, actor)
+	}
+
+	return s.applyDelete(ctx, current, reason, actor, nil)
+}
+
+// RestoreUser clears the soft delete of a user. The status is kept as it was. Erased users
+// cannot be restored, their personal data is gone.
+func (s *userService) RestoreUser(ctx context.Context, id string, actor *models.Actor) error {
+	userID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.ErrInvalidParams
+	}
+
+	current, err := s.userRepository.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to get user for restore")
+		return err
+	}
+
+	if current.ErasedAt != nil {
+		return models.ErrUserErased
+	}
+	if current.DeletedAt == nil {
+		return models.ErrUserNotDeleted
+	}
+
+	previous, err := s.userRepository.Restore(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return s.checkRestorable(ctx, userID)
+		}
+		return err
+	}
+
+	metadata := map[string]string{}
+	if previous.Deletion != nil {
+		metadata["deletion_reason"] = previous.Deletion.Reason
+		metadata["deleted_by"] = previous.Deletion.DeletedBy
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionRestoreUser,
+		TargetUserID: id,
+		TargetEmail:  previous.Email,
+		TargetRole:   previous.Role,
+		BeforeStatus: previous.Status,
+		AfterStatus:  previous.Status,
+		Metadata:     metadata,
+	})
+
+	s.invalidateStats(ctx)
+
+	logrus.Infof("User %s restored", id)
+	return nil
+}
+
+// getForDelete loads a not deleted user and checks that the actor may delete it
+func (s *userService) getForDelete(ctx context.Context, id string, actor *models.Actor) (*User, error) {
+	userID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	current, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to get user for deletion")
+		return nil, err
+	}
+
+	if actor.ID == id {
+		return nil, models.ErrSelfDelete
+	}
+	if current.IsStaff() {
+		return nil, models.ErrStaffDelete
+	}
+
+	return current, nil
+}
+
+// applyDelete soft deletes a validated user and runs the side effects
+func (s *userService) applyDelete(ctx context.Context, current *User, reason string,
+	actor *models.Actor, extraMetadata map[string]string) error {
+	id := current.ID.Hex()
+
+	var previous *User
+	err := s.outboxService.RunInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		previous, err = s.userRepository.SoftDelete(txCtx, current.ID, &Deletion{Reason: reason, DeletedBy: actor.ID})
+		if err != nil {
+			return err
+		}
+
+		return s.enqueueEvent(txCtx, id, events.TypeUserDeleted, &events.UserDeletedPayload{UserID: id, Reason: reason}, actor)
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrUserNotFound
+		}
+		return err
+	}
+
+	metadata := map[string]string{"reason": reason}
+	for key, value := range extraMetadata {
+		metadata[key] = value
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionDeleteUser,
+		TargetUserID: id,
+		TargetEmail:  previous.Email,
+		TargetRole:   previous.Role,
+		BeforeStatus: previous.Status,
+		AfterStatus:  previous.Status,
+		Metadata:     metadata,
+	})
+
+	s.invalidateStats(ctx)
+	s.revokeSessions(ctx, id, actor)
+
+	logrus.Infof("User %s soft deleted", id)
+	return nil
+}
+
+// checkRestorable explains why a restore matched no user: it was erased or restored
+// concurrently
+func (s *userService) checkRestorable(ctx context.Context, userID primitive.ObjectID) error {
+	current, err := s.userRepository.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrUserNotFound
+		}
+		return err
+	}
+	if current.ErasedAt != nil {
+		return models.ErrUserErased
+	}
+	return models.ErrUserNotDeleted
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/session"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErasedName replaces first and last names of erased users
+const ErasedName = "Erased"
+
+// erasedFields lists personal fields removed from erased users
+var erasedFields = []string{"first_name", "last_name", "email", "phone", "avatar", "suspension.note"}
+
+// AnonymizeUser irreversibly erases personal data of the user and logs the user out.
+// It returns the erased fields for the erasure certificate. A user already erased by
+// an earlier, interrupted attempt is only logged out again, so erasures can be retried.
+func (s *userService) AnonymizeUser(ctx context.Context, id string, actor *models.Actor) ([]string, error) {
+	userID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	set := bson.M{
+		"first_name": ErasedName,
+		"last_name":  ErasedName,
+		// Keeps the email unique without any trace of the original address
+		"email": fmt.Sprintf("erased-%s@erased.invalid", id),
+	}
+	unset := []string{"phone", "avatar", "suspension.note"}
+
+	err = s.userRepository.Anonymize(ctx, userID, set, unset)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = s.checkErased(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateStats(ctx)
+	if _, err := s.sessionService.RevokeAllForUser(ctx, id, session.ReasonUserErased, actor); err != nil {
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to revoke user sessions")
+	}
+
+	logrus.WithField("user_id", id).Info("User personal data erased")
+	return erasedFields, nil
+}
+
+// checkErased tells an already erased user apart from a missing one
+func (s *userService) checkErased(ctx context.Context, userID primitive.ObjectID) error {
+	existing, err := s.userRepository.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrUserNotFound
+		}
+		return err
+	}
+	if existing.ErasedAt == nil {
+		return models.ErrUserNotFound
+	}
+
+	logrus.WithField("user_id", userID.Hex()).Info("User personal data already erased")
+	return nil
+}
+
+// AnonymizeBulkJobs removes notes of bulk jobs that included the user; a note is shared
+// by every user of the job, so it is dropped for all of them
+func (s *userService) AnonymizeBulkJobs(ctx context.Context, id string) (int64, error) {
+	return s.bulkJobRepository.RemoveNotes(ctx, id)
+}
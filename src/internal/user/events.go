@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/events"
+	"handyhub-admin-svc/src/internal/models"
+)
+
+// enqueueEvent writes a domain event to the outbox; called with a transaction
+// context it is only published if the change is committed
+func (s *userService) enqueueEvent(ctx context.Context, id, eventType string, payload interface{}, actor *models.Actor) error {
+	routingKey := events.RoutingKey(&s.cfg.Messaging.Queues, eventType)
+	return s.outboxService.Enqueue(ctx, id, routingKey, events.New(eventType, actor, payload))
+}
+
+// statusEvent returns the event type and payload describing a status change
+func statusEvent(id, previousStatus, status string, suspension *Suspension) (string, interface{}) {
+	if status == StatusSuspended {
+		payload := &events.UserSuspendedPayload{UserID: id, PreviousStatus: previousStatus}
+		if suspension != nil {
+			payload.Reason = suspension.Reason
+			payload.Until = suspension.Until
+		}
+		return events.TypeUserSuspended, payload
+	}
+
+	eventType := events.TypeUserDeactivated
+	if status == StatusActive {
+		eventType = events.TypeUserActivated
+	}
+	return eventType, &events.UserStatusPayload{UserID: id, PreviousStatus: previousStatus, Status: status}
+}
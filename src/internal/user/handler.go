@@ -5,6 +5,7 @@ import (
 	"errors"
 	"handyhub-admin-svc/src/internal/cache"
 	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/middleware"
 	"handyhub-admin-svc/src/internal/models"
 	"net/http"
 	"strconv"
@@ -17,9 +18,16 @@ import (
 type Handler interface {
 	GetAllUsers(c *gin.Context)
 	GetUserStats(c *gin.Context)
+	GetUserStatsTimeSeries(c *gin.Context)
+	GetUserByID(c *gin.Context)
 	ActivateUser(c *gin.Context)
 	DeactivateUser(c *gin.Context)
 	SuspendUser(c *gin.Context)
+	BulkUpdateStatus(c *gin.Context)
+	GetBulkStatusJob(c *gin.Context)
+	ChangeUserRole(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	RestoreUser(c *gin.Context)
 }
 
 type handler struct {
@@ -42,23 +50,25 @@ func (h *handler) GetAllUsers(c *gin.Context) {
 
 	// Parse query parameters
 	req := &GetAllUsersRequest{
-		Page:      parseIntParam(c, "page", 1),
-		Limit:     parseIntParam(c, "limit", 20),
-		Role:      c.Query("role"),
-		Status:    c.Query("status"),
-		Search:    c.Query("search"),
-		SortBy:    c.Query("sortBy"),
-		SortOrder: c.Query("sortOrder"),
+		Page:           parseIntParam(c, "page", 1),
+		Limit:          parseIntParam(c, "limit", 20),
+		Role:           c.Query("role"),
+		Status:         c.Query("status"),
+		Search:         c.Query("search"),
+		SortBy:         c.Query("sortBy"),
+		SortOrder:      c.Query("sortOrder"),
+		IncludeDeleted: c.Query("includeDeleted"),
 	}
 
 	logrus.WithFields(logrus.Fields{
-		"page":   req.Page,
-		"limit":  req.Limit,
-		"role":   req.Role,
-		"status": req.Status,
-		"search": req.Search,
-		"sortBy": req.SortBy,
-		"order":  req.SortOrder,
+		"page":    req.Page,
+		"limit":   req.Limit,
+		"role":    req.Role,
+		"status":  req.Status,
+		"search":  req.Search,
+		"sortBy":  req.SortBy,
+		"order":   req.SortOrder,
+		"deleted": req.IncludeDeleted,
 	}).Info("GetAllUsers request received")
 
 	// Get admin user info from context
@@ -123,7 +133,8 @@ func (h *handler) GetUserStats(c *gin.Context) {
 		"admin_email":   userEmail,
 	}).Debug("Admin user accessing GetUserStats")
 
-	userStats, err := h.cacheService.GetUserStats(ctx)
+	refresh := c.Query("refresh") == "true"
+	userStats, err := h.getCachedUserStats(ctx, refresh)
 	if err == nil && userStats != nil {
 		logrus.Debug("User statistics retrieved from cache")
 		c.JSON(http.StatusOK, gin.H{
@@ -153,19 +164,365 @@ func (h *handler) GetUserStats(c *gin.Context) {
 	})
 }
 
+func (h *handler) GetUserByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	userID := c.Param("id")
+	adminID, _ := c.Get("user_id")
+	logrus.WithFields(logrus.Fields{
+		"user_id":       userID,
+		"admin_user_id": adminID,
+	}).Info("GetUserByID request received")
+
+	details, err := h.service.GetUserByID(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to get user details")
+		switch {
+		case errors.Is(err, models.ErrUserNotFound):
+			h.sendErrorResponse(c, http.StatusNotFound, "User not found", "No user found with the provided ID")
+		case errors.Is(err, models.ErrInvalidParams):
+			h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
+		default:
+			h.sendErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve user", err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    details,
+		"message": "User retrieved successfully",
+	})
+}
+
+func (h *handler) GetUserStatsTimeSeries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	query := &models.TimeSeriesQuery{Interval: c.Query("interval")}
+
+	var err error
+	if query.From, err = parseDateParam(c, "from", false); err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid 'from' parameter", "Expected RFC3339 timestamp or YYYY-MM-DD date")
+		return
+	}
+	if query.To, err = parseDateParam(c, "to", true); err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid 'to' parameter", "Expected RFC3339 timestamp or YYYY-MM-DD date")
+		return
+	}
+
+	if err := NormalizeTimeSeriesQuery(query); err != nil {
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid time series query",
+			"Use interval day, week or month with a range of at most 366 buckets")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"from": query.From, "to": query.To, "interval": query.Interval,
+	}).Info("GetUserStatsTimeSeries request received")
+
+	refresh := c.Query("refresh") == "true"
+
+	cached, err := h.getCachedTimeSeries(ctx, query, refresh)
+	if err == nil && cached != nil {
+		logrus.Debug("User statistics time series retrieved from cache")
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    cached,
+			"message": "User statistics time series retrieved successfully (from cache)",
+		})
+		return
+	}
+
+	series, err := h.service.GetUserStatsTimeSeries(ctx, query)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get user statistics time series")
+		h.sendErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve user statistics time series", err.Error())
+		return
+	}
+
+	h.cacheService.SaveUserStatsTimeSeries(ctx, query, series)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+		"message": "User statistics time series retrieved successfully",
+	})
+}
+
+func (h *handler) getCachedUserStats(ctx context.Context, refresh bool) (*models.Stats, error) {
+	if refresh {
+		return nil, nil
+	}
+	return h.cacheService.GetUserStats(ctx)
+}
+
+func (h *handler) getCachedTimeSeries(ctx context.Context, query *models.TimeSeriesQuery, refresh bool) (*models.TimeSeries, error) {
+	if refresh {
+		return nil, nil
+	}
+	return h.cacheService.GetUserStatsTimeSeries(ctx, query)
+}
+
+// parseDateParam accepts RFC3339 timestamps or plain YYYY-MM-DD dates. A plain date
+// that ends a range includes the whole day.
+func parseDateParam(c *gin.Context, param string, end bool) (time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return parsed, nil
+}
+
 func (h *handler) ActivateUser(c *gin.Context) {
-	h.updateUserStatusHandler(c, StatusActive, "User activated successfully")
+	h.updateUserStatusHandler(c, StatusActive, "User activated successfully", nil)
 }
 
 func (h *handler) DeactivateUser(c *gin.Context) {
-	h.updateUserStatusHandler(c, StatusInactive, "User deactivated successfully")
+	h.updateUserStatusHandler(c, StatusInactive, "User deactivated successfully", nil)
 }
 
 func (h *handler) SuspendUser(c *gin.Context) {
-	h.updateUserStatusHandler(c, StatusSuspended, "User suspended successfully")
+	var req SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Warn("Invalid suspend user request body")
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", "A suspension reason is required")
+		return
+	}
+
+	h.updateUserStatusHandler(c, StatusSuspended, "User suspended successfully", &req)
+}
+
+func (h *handler) DeleteUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req DeleteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Warn("Invalid delete user request body")
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", "A deletion reason is required")
+		return
+	}
+
+	userID := c.Param("id")
+	logrus.WithField("user_id", userID).Info("DeleteUser request received")
+
+	if err := h.service.DeleteUser(ctx, userID, &req, middleware.ActorFromContext(c)); err != nil {
+		h.handleDeletionError(c, userID, err, "Failed to delete user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "User deleted successfully",
+	})
+}
+
+func (h *handler) RestoreUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	userID := c.Param("id")
+	logrus.WithField("user_id", userID).Info("RestoreUser request received")
+
+	if err := h.service.RestoreUser(ctx, userID, middleware.ActorFromContext(c)); err != nil {
+		h.handleDeletionError(c, userID, err, "Failed to restore user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "User restored successfully",
+	})
+}
+
+func (h *handler) handleDeletionError(c *gin.Context, userID string, err error, message string) {
+	var approvalErr *models.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID, "approval_id": approvalErr.ApprovalID,
+		}).Info("User deletion is pending approval")
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"success": true,
+			"data":    gin.H{"approvalId": approvalErr.ApprovalID},
+			"message": "User deletion requires approval by a second admin",
+		})
+		return
+	}
+
+	logrus.WithError(err).WithField("user_id", userID).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "User not found", "No user found with the provided ID")
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
+	case errors.Is(err, models.ErrDeletionReasonRequired):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid deletion", err.Error())
+	case errors.Is(err, models.ErrUserNotDeleted):
+		h.sendErrorResponse(c, http.StatusConflict, "User is not deleted", err.Error())
+	case errors.Is(err, models.ErrUserErased):
+		h.sendErrorResponse(c, http.StatusConflict, "User is erased", err.Error())
+	case errors.Is(err, models.ErrSelfDelete), errors.Is(err, models.ErrStaffDelete):
+		h.sendErrorResponse(c, http.StatusForbidden, "Deletion not permitted", err.Error())
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) ChangeUserRole(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Warn("Invalid change role request body")
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", "A role is required")
+		return
+	}
+
+	userID := c.Param("id")
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID, "role": req.Role,
+	}).Info("ChangeUserRole request received")
+
+	if err := h.service.ChangeUserRole(ctx, userID, &req, middleware.ActorFromContext(c)); err != nil {
+		h.handleRoleChangeError(c, userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "User role changed successfully",
+	})
+}
+
+func (h *handler) handleRoleChangeError(c *gin.Context, userID string, err error) {
+	logrus.WithError(err).WithField("user_id", userID).Error("Failed to change user role")
+
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "User not found", "No user found with the provided ID")
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
+	case errors.Is(err, models.ErrInvalidRole):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid role", err.Error())
+	case errors.Is(err, models.ErrRoleUnchanged), errors.Is(err, models.ErrRoleConflict), errors.Is(err, models.ErrLastAdmin):
+		h.sendErrorResponse(c, http.StatusConflict, "Role change conflict", err.Error())
+	case errors.Is(err, models.ErrSelfRoleChange), errors.Is(err, models.ErrRoleChangeForbidden):
+		h.sendErrorResponse(c, http.StatusForbidden, "Role change not permitted", err.Error())
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, "Failed to change user role", err.Error())
+	}
+}
+
+func (h *handler) BulkUpdateStatus(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	var req BulkStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Warn("Invalid bulk status request body")
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid request body", "A target status is required")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"status": req.Status, "user_ids": len(req.UserIDs), "filter": req.Filter != nil, "dry_run": req.DryRun,
+	}).Info("BulkUpdateStatus request received")
+
+	response, err := h.service.BulkUpdateStatus(ctx, &req, middleware.ActorFromContext(c))
+	if err != nil {
+		h.handleBulkError(c, err, "Failed to update user statuses")
+		return
+	}
+
+	switch {
+	case response.DryRun:
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    response,
+			"message": "Dry run completed, no users were changed",
+		})
+	case response.ApprovalID != "":
+		c.JSON(http.StatusAccepted, gin.H{
+			"success": true,
+			"data":    response,
+			"message": "Bulk status update is pending approval",
+		})
+	case response.JobID != "" && response.Summary != nil:
+		c.JSON(http.StatusAccepted, gin.H{
+			"success": true,
+			"data":    response,
+			"message": "Bulk status update partly completed, remaining users queued",
+		})
+	case response.JobID != "":
+		c.JSON(http.StatusAccepted, gin.H{
+			"success": true,
+			"data":    response,
+			"message": "Bulk status update queued",
+		})
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    response,
+			"message": "Bulk status update completed",
+		})
+	}
 }
 
-func (h *handler) updateUserStatusHandler(c *gin.Context, status, successMessage string) {
+func (h *handler) GetBulkStatusJob(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.config.App.Timeout)*time.Second)
+	defer cancel()
+
+	jobID := c.Param("jobId")
+	logrus.WithField("job_id", jobID).Info("GetBulkStatusJob request received")
+
+	job, err := h.service.GetBulkJob(ctx, jobID)
+	if err != nil {
+		h.handleBulkError(c, err, "Failed to retrieve bulk job")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+		"message": "Bulk job retrieved successfully",
+	})
+}
+
+func (h *handler) handleBulkError(c *gin.Context, err error, message string) {
+	logrus.WithError(err).Error(message)
+
+	switch {
+	case errors.Is(err, models.ErrInvalidParams):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid job ID", "Please provide a valid job ID")
+	case errors.Is(err, models.ErrInvalidUserStatus), errors.Is(err, models.ErrInvalidRole),
+		errors.Is(err, models.ErrBulkTargetsRequired), errors.Is(err, models.ErrBulkTooManyUsers),
+		errors.Is(err, models.ErrSuspensionReasonRequired), errors.Is(err, models.ErrInvalidSuspensionEnd):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid bulk request", err.Error())
+	case errors.Is(err, models.ErrBulkJobNotFound):
+		h.sendErrorResponse(c, http.StatusNotFound, "Bulk job not found", "No bulk job found with the provided ID")
+	default:
+		h.sendErrorResponse(c, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+func (h *handler) updateUserStatusHandler(c *gin.Context, status, successMessage string, suspendReq *SuspendUserRequest) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(),
 		time.Duration(h.config.App.Timeout)*time.Second)
 	defer cancel()
@@ -182,7 +539,7 @@ func (h *handler) updateUserStatusHandler(c *gin.Context, status, successMessage
 		"status":  status,
 	}).Info("Updating user status")
 
-	err := h.executeStatusUpdate(ctx, userID, status)
+	err := h.executeStatusUpdate(ctx, userID, status, suspendReq, middleware.ActorFromContext(c))
 
 	if err != nil {
 		h.handleStatusUpdateError(c, userID, status, err)
@@ -200,20 +557,34 @@ func (h *handler) updateUserStatusHandler(c *gin.Context, status, successMessage
 	})
 }
 
-func (h *handler) executeStatusUpdate(ctx context.Context, userID, status string) error {
+func (h *handler) executeStatusUpdate(ctx context.Context, userID, status string, suspendReq *SuspendUserRequest, actor *models.Actor) error {
 	switch status {
 	case StatusActive:
-		return h.service.ActivateUser(ctx, userID)
+		return h.service.ActivateUser(ctx, userID, actor)
 	case StatusInactive:
-		return h.service.DeactivateUser(ctx, userID)
+		return h.service.DeactivateUser(ctx, userID, actor)
 	case StatusSuspended:
-		return h.service.SuspendUser(ctx, userID)
+		return h.service.SuspendUser(ctx, userID, suspendReq, actor)
 	default:
 		return models.ErrInvalidUserStatus
 	}
 }
 
 func (h *handler) handleStatusUpdateError(c *gin.Context, userID, status string, err error) {
+	var approvalErr *models.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID, "status": status, "approval_id": approvalErr.ApprovalID,
+		}).Info("User status change is pending approval")
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"success": true,
+			"data":    gin.H{"approvalId": approvalErr.ApprovalID},
+			"message": "Status change requires approval by a second admin",
+		})
+		return
+	}
+
 	logrus.WithError(err).WithFields(logrus.Fields{
 		"user_id": userID,
 		"status":  status,
@@ -224,7 +595,14 @@ func (h *handler) handleStatusUpdateError(c *gin.Context, userID, status string,
 		h.sendErrorResponse(c, http.StatusNotFound, "User not found", "No user found with the provided ID")
 	case errors.Is(err, models.ErrInvalidParams):
 		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
-		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "Please provide a valid user ID")
+	case errors.Is(err, models.ErrSuspensionReasonRequired), errors.Is(err, models.ErrInvalidSuspensionEnd):
+		h.sendErrorResponse(c, http.StatusBadRequest, "Invalid suspension", err.Error())
+	case errors.Is(err, models.ErrInvalidStatusTransition):
+		h.sendErrorResponse(c, http.StatusConflict, "Invalid status transition", err.Error())
+	case errors.Is(err, models.ErrStatusConflict):
+		h.sendErrorResponse(c, http.StatusConflict, "Status update conflict", "User status was changed by another request, please reload and retry")
+	case errors.Is(err, models.ErrSelfStatusChange), errors.Is(err, models.ErrAdminStatusChange):
+		h.sendErrorResponse(c, http.StatusForbidden, "Status change not permitted", err.Error())
 	default:
 		h.sendErrorResponse(c, http.StatusInternalServerError, "Failed to update user status", err.Error())
 	}
@@ -233,7 +611,7 @@ func (h *handler) handleStatusUpdateError(c *gin.Context, userID, status string,
 func (h *handler) sendErrorResponse(c *gin.Context, statusCode int, error, message string) {
 	c.JSON(statusCode, gin.H{
 		"error":   error,
-		" ":       false,
+		"success": false,
 		"message": message,
 	})
 }
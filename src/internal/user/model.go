@@ -27,6 +27,33 @@ type User struct {
 	CreatedAt           time.Time          `json:"createdAt" bson:"created_at"`
 	UpdatedAt           time.Time          `json:"updatedAt" bson:"updated_at"`
 	DeletedAt           *time.Time         `json:"deletedAt,omitempty" bson:"deleted_at,omitempty"`
+	StatusChangedAt     *time.Time         `json:"-" bson:"status_changed_at,omitempty"`
+	Suspension          *Suspension        `json:"suspension,omitempty" bson:"suspension,omitempty"`
+	Deletion            *Deletion          `json:"deletion,omitempty" bson:"deletion,omitempty"`
+	ErasedAt            *time.Time         `json:"erasedAt,omitempty" bson:"erased_at,omitempty"`
+}
+
+// Suspension holds details of the current user suspension
+type Suspension struct {
+	Reason      string     `json:"reason" bson:"reason"`
+	Note        string     `json:"note,omitempty" bson:"note,omitempty"`
+	Until       *time.Time `json:"until,omitempty" bson:"until,omitempty"`
+	SuspendedAt time.Time  `json:"suspendedAt" bson:"suspended_at"`
+	SuspendedBy string     `json:"suspendedBy" bson:"suspended_by"`
+}
+
+// Deletion holds details of a soft delete
+type Deletion struct {
+	Reason    string `json:"reason" bson:"reason"`
+	DeletedBy string `json:"deletedBy" bson:"deleted_by"`
+}
+
+// Details represents the full admin view of a single user
+type Details struct {
+	*User
+	ActiveSessions int64 `json:"activeSessions"`
+	// LastStatusChangeAt is omitted for users whose status has not changed since it is tracked
+	LastStatusChangeAt *time.Time `json:"lastStatusChangeAt,omitempty"`
 }
 
 type Profile struct {
@@ -45,8 +72,12 @@ type Profile struct {
 	Avatar           *string            `json:"avatar,omitempty"`
 	TimeZone         string             `json:"timeZone"`
 	Language         string             `json:"language"`
+	Suspension       *Suspension        `json:"suspension,omitempty"`
 	CreatedAt        time.Time          `json:"createdAt"`
 	UpdatedAt        time.Time          `json:"updatedAt"`
+	DeletedAt        *time.Time         `json:"deletedAt,omitempty"`
+	Deletion         *Deletion          `json:"deletion,omitempty"`
+	ErasedAt         *time.Time         `json:"erasedAt,omitempty"`
 }
 
 // Role constants
@@ -54,6 +85,10 @@ const (
 	RoleAdmin    = "admin"
 	RoleClient   = "client"
 	RoleExecutor = "executor"
+
+	RoleSupport    = "support"
+	RoleModerator  = "moderator"
+	RoleSuperAdmin = "superadmin"
 )
 
 // Status constants
@@ -65,14 +100,26 @@ const (
 
 // GetAllUsersRequest represents request for getting all users
 type GetAllUsersRequest struct {
-	Page          int    `json:"page" form:"page"`
-	Limit         int    `json:"limit" form:"limit"`
-	Role          string `json:"role" form:"role"`
-	Status        string `json:"status" form:"status"`
-	Search        string `json:"search" form:"search"`
-	SortBy        string `json:"sortBy" form:"sortBy"`
-	SortOrder     string `json:"sortOrder" form:"sortOrder"`
-	SortDirection int    `json:"-" bson:"-"`
+	Page           int    `json:"page" form:"page"`
+	Limit          int    `json:"limit" form:"limit"`
+	Role           string `json:"role" form:"role"`
+	Status         string `json:"status" form:"status"`
+	Search         string `json:"search" form:"search"`
+	SortBy         string `json:"sortBy" form:"sortBy"`
+	SortOrder      string `json:"sortOrder" form:"sortOrder"`
+	IncludeDeleted string `json:"includeDeleted" form:"includeDeleted"`
+	SortDirection  int    `json:"-" bson:"-"`
+}
+
+// IncludeDeleted options of the user list
+const (
+	IncludeDeletedTrue = "true"
+	IncludeDeletedOnly = "only"
+)
+
+// DeleteUserRequest represents request body for soft deleting a user
+type DeleteUserRequest struct {
+	Reason string `json:"reason" binding:"required"`
 }
 
 // GetAllUsersResponse represents response for getting all users
@@ -84,6 +131,28 @@ type GetAllUsersResponse struct {
 	TotalPages int        `json:"totalPages"`
 }
 
+// ChangeRoleRequest represents request body for changing a user role
+type ChangeRoleRequest struct {
+	Role   string `json:"role" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// RoleChangedMessage is published so the auth service can invalidate issued tokens
+type RoleChangedMessage struct {
+	UserID    string    `json:"user_id"`
+	OldRole   string    `json:"old_role"`
+	NewRole   string    `json:"new_role"`
+	ChangedBy string    `json:"changed_by"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SuspendUserRequest represents request body for suspending a user
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" binding:"required"`
+	Note   string     `json:"note"`
+	Until  *time.Time `json:"until"`
+}
+
 const (
 	SortByRegistrationDate = "registration_date"
 	SortByFirstName        = "first_name"
@@ -117,14 +186,36 @@ func (u *User) ToProfile() *Profile {
 		Avatar:           u.Avatar,
 		TimeZone:         u.TimeZone,
 		Language:         u.Language,
+		Suspension:       u.Suspension,
 		CreatedAt:        u.CreatedAt,
 		UpdatedAt:        u.UpdatedAt,
+		DeletedAt:        u.DeletedAt,
+		Deletion:         u.Deletion,
+		ErasedAt:         u.ErasedAt,
+	}
+}
+
+// ToDetails converts User to the admin Details view
+func (u *User) ToDetails(activeSessions int64) *Details {
+	return &Details{
+		User:               u,
+		ActiveSessions:     activeSessions,
+		LastStatusChangeAt: u.StatusChangedAt,
 	}
 }
 
 // IsAdmin checks if user is admin
 func (u *User) IsAdmin() bool {
-	return u.Role == RoleAdmin
+	return u.Role == RoleAdmin || u.Role == RoleSuperAdmin
+}
+
+// IsStaff checks if user belongs to the admin panel staff
+func (u *User) IsStaff() bool {
+	switch u.Role {
+	case RoleSupport, RoleModerator, RoleAdmin, RoleSuperAdmin:
+		return true
+	}
+	return false
 }
 
 // IsActive checks if user is active
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"handyhub-admin-svc/src/clients"
 	"handyhub-admin-svc/src/internal/models"
 	"math"
@@ -22,8 +23,19 @@ const (
 type Repository interface {
 	GetAllUsers(ctx context.Context, req *GetAllUsersRequest) ([]*User, int64, error)
 	GetUserStats(ctx context.Context) (*models.Stats, error)
+	GetRegistrationSeries(ctx context.Context, query *models.TimeSeriesQuery) ([]models.TimeSeriesResult, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*User, error)
-	UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error
+	GetByIDIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*User, error)
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, expectedStatus, status string, suspension *Suspension) (*User, error)
+	FindExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]*User, error)
+	FindIDsByFilter(ctx context.Context, filter *BulkFilter, limit int) ([]string, error)
+	CountByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+	FindRolesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]string, error)
+	UpdateRole(ctx context.Context, id primitive.ObjectID, expectedRole, role string) (*User, error)
+	LockActiveAdmins(ctx context.Context) (int64, error)
+	SoftDelete(ctx context.Context, id primitive.ObjectID, deletion *Deletion) (*User, error)
+	Restore(ctx context.Context, id primitive.ObjectID) (*User, error)
+	Anonymize(ctx context.Context, id primitive.ObjectID, set bson.M, unset []string) error
 }
 
 type userRepository struct {
@@ -41,23 +53,7 @@ func (r *userRepository) GetAllUsers(ctx context.Context, req *GetAllUsersReques
 	collection := r.Collection
 
 	// Build filter
-	filter := bson.M{"deleted_at": bson.M{"$exists": false}}
-
-	if req.Role != "" {
-		filter["role"] = req.Role
-	}
-
-	if req.Status != "" {
-		filter["status"] = req.Status
-	}
-
-	if req.Search != "" {
-		filter["$or"] = []bson.M{
-			{"first_name": bson.M{regexKey: req.Search, optionsKey: "i"}},
-			{"last_name": bson.M{regexKey: req.Search, optionsKey: "i"}},
-			{"email": bson.M{regexKey: req.Search, optionsKey: "i"}},
-		}
-	}
+	filter := usersFilter(req.Role, req.Status, req.Search, req.IncludeDeleted)
 
 	// Count total documents
 	totalCount, err := collection.CountDocuments(ctx, filter)
@@ -123,30 +119,55 @@ func (r *userRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*U
 	return &user, nil
 }
 
-func (r *userRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+func (r *userRepository) GetByIDIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*User, error) {
+	var user User
+	err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateStatus sets the user status only if it still equals expectedStatus and returns
+// the user as it was before the update. Suspension details are stored for suspended
+// users and cleared for any other status.
+func (r *userRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, expectedStatus, status string, suspension *Suspension) (*User, error) {
 	collection := r.Collection
 
 	filter := bson.M{
 		"_id":        id,
+		"status":     expectedStatus,
 		"deleted_at": bson.M{"$exists": false}, // Exclude soft deleted users
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"status":     status,
-			"updated_at": time.Now(),
-		},
+	now := time.Now()
+	set := bson.M{
+		"status":            status,
+		"status_changed_at": now,
+		"updated_at":        now,
 	}
 
-	result, err := collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		logrus.WithError(err).WithField("user_id", id.Hex()).Error("Failed to update user status")
-		return err
+	update := bson.M{"$set": set}
+	if status == StatusSuspended && suspension != nil {
+		set["suspension"] = suspension
+	} else {
+		update["$unset"] = bson.M{"suspension": ""}
 	}
 
-	if result.MatchedCount == 0 {
-		logrus.WithField("user_id", id.Hex()).Warn("No user found to update status")
-		return mongo.ErrNoDocuments
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous User
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logrus.WithFields(logrus.Fields{
+				"user_id": id.Hex(), "expected_status": expectedStatus,
+			}).Warn("No user found in expected status to update")
+		} else {
+			logrus.WithError(err).WithField("user_id", id.Hex()).Error("Failed to update user status")
+		}
+		return nil, err
 	}
 
 	logrus.WithFields(logrus.Fields{
@@ -154,9 +175,231 @@ func (r *userRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID
 		"status":  status,
 	}).Info("User status updated successfully")
 
+	return &previous, nil
+}
+
+// UpdateRole sets the user role only if it still equals expectedRole and returns
+// the user as it was before the update
+func (r *userRepository) UpdateRole(ctx context.Context, id primitive.ObjectID, expectedRole, role string) (*User, error) {
+	filter := bson.M{
+		"_id":        id,
+		"role":       expectedRole,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"role": role, "updated_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous User
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			logrus.WithError(err).WithField("user_id", id.Hex()).Error("Failed to update user role")
+		}
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": id.Hex(), "old_role": previous.Role, "new_role": role,
+	}).Info("User role updated successfully")
+
+	return &previous, nil
+}
+
+// SoftDelete marks a not deleted user as deleted and returns the user as it was before
+func (r *userRepository) SoftDelete(ctx context.Context, id primitive.ObjectID, deletion *Deletion) (*User, error) {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"deleted_at": now,
+		"deletion":   deletion,
+		"updated_at": now,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous User
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			logrus.WithError(err).WithField("user_id", id.Hex()).Error("Failed to soft delete user")
+		}
+		return nil, err
+	}
+
+	logrus.WithField("user_id", id.Hex()).Info("User soft deleted successfully")
+	return &previous, nil
+}
+
+// Restore clears the soft delete of a deleted, not erased user and returns the user as it was before
+func (r *userRepository) Restore(ctx context.Context, id primitive.ObjectID) (*User, error) {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": true},
+		"erased_at":  bson.M{"$exists": false},
+	}
+
+	update := bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": "", "deletion": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous User
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			logrus.WithError(err).WithField("user_id", id.Hex()).Error("Failed to restore user")
+		}
+		return nil, err
+	}
+
+	logrus.WithField("user_id", id.Hex()).Info("User restored successfully")
+	return &previous, nil
+}
+
+// Anonymize overwrites and removes personal fields of a not yet erased user, including
+// soft deleted ones. Status, role and dates are untouched so statistics stay consistent.
+func (r *userRepository) Anonymize(ctx context.Context, id primitive.ObjectID, set bson.M, unset []string) error {
+	filter := bson.M{
+		"_id":       id,
+		"erased_at": bson.M{"$exists": false},
+	}
+
+	now := time.Now()
+	set["erased_at"] = now
+	set["updated_at"] = now
+
+	unsetFields := bson.M{}
+	for _, field := range unset {
+		unsetFields[field] = ""
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, bson.M{"$set": set, "$unset": unsetFields})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", id.Hex()).Error("Failed to anonymize user")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	logrus.WithField("user_id", id.Hex()).Info("User anonymized successfully")
 	return nil
 }
 
+// LockActiveAdmins counts active, not deleted admins and superadmins by bumping a lock
+// counter on each of them. Called in a transaction, it makes concurrent role changes of
+// any of these admins conflict instead of both passing the last admin check.
+func (r *userRepository) LockActiveAdmins(ctx context.Context) (int64, error) {
+	filter := bson.M{
+		"role":       bson.M{"$in": []string{RoleAdmin, RoleSuperAdmin}},
+		"status":     StatusActive,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$inc": bson.M{"admin_lock": 1}}
+
+	result, err := r.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to lock active admins")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// FindIDsByFilter returns IDs of users matching the list filter, up to limit
+func (r *userRepository) FindIDsByFilter(ctx context.Context, filter *BulkFilter, limit int) ([]string, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.Collection.Find(ctx, usersFilter(filter.Role, filter.Status, filter.Search, ""), opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find users by filter")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		logrus.WithError(err).Error("Failed to decode user IDs")
+		return nil, err
+	}
+
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID.Hex()
+	}
+	return ids, nil
+}
+
+// CountByIDs counts users that exist and are not soft deleted
+func (r *userRepository) CountByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	filter := bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	count, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to count users by IDs")
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindRolesByIDs returns the distinct roles of the users that exist and are not soft deleted
+func (r *userRepository) FindRolesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]string, error) {
+	filter := bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	values, err := r.Collection.Distinct(ctx, "role", filter)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find roles by user IDs")
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(values))
+	for _, value := range values {
+		if role, ok := value.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// FindExpiredSuspensions returns suspended users whose suspension end has passed
+func (r *userRepository) FindExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]*User, error) {
+	filter := bson.M{
+		"status":           StatusSuspended,
+		"suspension.until": bson.M{"$lte": now},
+		"deleted_at":       bson.M{"$exists": false},
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"suspension.until": 1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to find users with expired suspensions")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*User
+	if err := cursor.All(ctx, &users); err != nil {
+		logrus.WithError(err).Error("Failed to decode users with expired suspensions")
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) GetUserStats(ctx context.Context) (*models.Stats, error) {
 	now := time.Now()
 	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
@@ -268,6 +511,78 @@ func (r *userRepository) GetUserStats(ctx context.Context) (*models.Stats, error
 	return stats, nil
 }
 
+// GetRegistrationSeries counts registrations per interval bucket and role
+func (r *userRepository) GetRegistrationSeries(ctx context.Context, query *models.TimeSeriesQuery) ([]models.TimeSeriesResult, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"deleted_at": bson.M{"$exists": false},
+			"created_at": bson.M{"$gte": query.From, "$lt": query.To},
+		}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"bucket": bson.M{"$dateTrunc": bson.M{
+					"date": "$created_at", "unit": query.Interval, "startOfWeek": "monday",
+				}},
+				"role": "$role",
+			},
+			"count": bson.M{"$sum": 1},
+		}},
+		{"$project": bson.M{
+			"_id":    0,
+			"bucket": "$_id.bucket",
+			"role":   "$_id.role",
+			"count":  1,
+		}},
+	}
+
+	cursor, err := r.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to execute aggregation for registration series")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []models.TimeSeriesResult
+	if err := cursor.All(ctx, &results); err != nil {
+		logrus.WithError(err).Error("Failed to decode registration series")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// usersFilter builds the filter shared by the user list and bulk updates.
+// Soft deleted users are excluded unless includeDeleted is "true" or "only".
+func usersFilter(role, status, search, includeDeleted string) bson.M {
+	filter := bson.M{}
+
+	switch includeDeleted {
+	case IncludeDeletedTrue:
+	case IncludeDeletedOnly:
+		filter["deleted_at"] = bson.M{"$exists": true}
+	default:
+		filter["deleted_at"] = bson.M{"$exists": false}
+	}
+
+	if role != "" {
+		filter["role"] = role
+	}
+
+	if status != "" {
+		filter["status"] = status
+	}
+
+	if search != "" {
+		filter["$or"] = []bson.M{
+			{"first_name": bson.M{regexKey: search, optionsKey: "i"}},
+			{"last_name": bson.M{regexKey: search, optionsKey: "i"}},
+			{"email": bson.M{regexKey: search, optionsKey: "i"}},
+		}
+	}
+
+	return filter
+}
+
 func (r *userRepository) calculatePercentageGrowth(previous, current int64) float64 {
 	if previous == 0 {
 		if current > 0 {
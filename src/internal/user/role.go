@@ -0,0 +1,128 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/events"
+	"handyhub-admin-svc/src/internal/models"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ChangeUserRole assigns a new role to the user. Admins cannot change their own role,
+// only superadmins manage the superadmin role, and the last active admin is kept.
+func (s *userService) ChangeUserRole(ctx context.Context, id string, req *ChangeRoleRequest, actor *models.Actor) error {
+	if !isValidRole(req.Role) {
+		return models.ErrInvalidRole
+	}
+
+	userID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.ErrInvalidParams
+	}
+
+	current, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to get user for role change")
+		return err
+	}
+
+	if err := checkRoleChange(actor, current, req.Role); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"user_id": id, "from": current.Role, "to": req.Role, "actor_id": actor.ID,
+		}).Warn("User role change rejected")
+		return err
+	}
+
+	// The update, the last admin check and the events are committed together. A demotion
+	// locks the remaining active admins, so of two concurrent demotions one conflicts, is
+	// retried and then sees that it would remove the last admin.
+	demotesAdmin := current.IsAdmin() && req.Role != RoleAdmin && req.Role != RoleSuperAdmin
+	var previous *User
+	err = s.outboxService.RunInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		previous, err = s.userRepository.UpdateRole(txCtx, userID, current.Role, req.Role)
+		if err != nil {
+			return err
+		}
+
+		if demotesAdmin && previous.IsActive() {
+			admins, err := s.userRepository.LockActiveAdmins(txCtx)
+			if err != nil {
+				return err
+			}
+			if admins == 0 {
+				return models.ErrLastAdmin
+			}
+		}
+
+		return s.enqueueRoleChanged(txCtx, id, previous.Role, req.Role, actor)
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrRoleConflict
+		}
+		return err
+	}
+
+	metadata := map[string]string{"old_role": previous.Role, "new_role": req.Role}
+	if reason := strings.TrimSpace(req.Reason); reason != "" {
+		metadata["reason"] = reason
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       audit.ActionChangeRole,
+		TargetUserID: id,
+		TargetEmail:  previous.Email,
+		TargetRole:   previous.Role,
+		Metadata:     metadata,
+	})
+
+	s.invalidateStats(ctx)
+
+	logrus.Infof("User %s role changed from %s to %s", id, previous.Role, req.Role)
+	return nil
+}
+
+func checkRoleChange(actor *models.Actor, target *User, role string) error {
+	if target.Role == role {
+		return models.ErrRoleUnchanged
+	}
+
+	if actor.ID == target.ID.Hex() {
+		return models.ErrSelfRoleChange
+	}
+
+	if (target.Role == RoleSuperAdmin || role == RoleSuperAdmin) && actor.Role != RoleSuperAdmin {
+		return models.ErrRoleChangeForbidden
+	}
+
+	return nil
+}
+
+// enqueueRoleChanged writes the role change to the outbox twice: as the RoleChangedMessage
+// the auth service consumes to invalidate issued tokens, and as the user.role_changed event
+func (s *userService) enqueueRoleChanged(ctx context.Context, id, oldRole, newRole string, actor *models.Actor) error {
+	message := &RoleChangedMessage{
+		UserID:    id,
+		OldRole:   oldRole,
+		NewRole:   newRole,
+		ChangedBy: actor.ID,
+		Timestamp: time.Now(),
+	}
+	if err := s.outboxService.Enqueue(ctx, id, s.cfg.Messaging.Queues.RoleChanged.RoutingKey, message); err != nil {
+		return err
+	}
+
+	payload := &events.UserRoleChangedPayload{UserID: id, PreviousRole: oldRole, Role: newRole}
+	return s.enqueueEvent(ctx, id, events.TypeUserRoleChanged, payload, actor)
+}
@@ -2,31 +2,71 @@ package user
 
 import (
 	"context"
+	"errors"
+	"handyhub-admin-svc/src/internal/approval"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/cache"
 	"handyhub-admin-svc/src/internal/config"
 	"handyhub-admin-svc/src/internal/models"
+	"handyhub-admin-svc/src/internal/outbox"
+	"handyhub-admin-svc/src/internal/session"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type Service interface {
 	GetAllUsers(ctx context.Context, req *GetAllUsersRequest) (*GetAllUsersResponse, error)
 	GetUserStats(ctx context.Context) (*models.Stats, error)
-	ActivateUser(ctx context.Context, id string) error
-	DeactivateUser(ctx context.Context, id string) error
-	SuspendUser(ctx context.Context, id string) error
+	GetUserStatsTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error)
+	GetUserByID(ctx context.Context, id string) (*Details, error)
+	ActivateUser(ctx context.Context, id string, actor *models.Actor) error
+	DeactivateUser(ctx context.Context, id string, actor *models.Actor) error
+	SuspendUser(ctx context.Context, id string, req *SuspendUserRequest, actor *models.Actor) error
+	ReactivateExpiredSuspensions(ctx context.Context) (int, error)
+	ExecuteApproved(ctx context.Context, action *approval.PendingAction, reviewer *models.Actor) error
+	BulkUpdateStatus(ctx context.Context, req *BulkStatusRequest, actor *models.Actor) (*BulkStatusResponse, error)
+	GetBulkJob(ctx context.Context, id string) (*BulkJob, error)
+	RunNextBulkJob(ctx context.Context) (bool, error)
+	ChangeUserRole(ctx context.Context, id string, req *ChangeRoleRequest, actor *models.Actor) error
+	DeleteUser(ctx context.Context, id string, req *DeleteUserRequest, actor *models.Actor) error
+	RestoreUser(ctx context.Context, id string, actor *models.Actor) error
+	AnonymizeUser(ctx context.Context, id string, actor *models.Actor) ([]string, error)
+	AnonymizeBulkJobs(ctx context.Context, id string) (int64, error)
 }
 
 type userService struct {
-	userRepository Repository
-	cfg            *config.Configuration
+	userRepository    Repository
+	bulkJobRepository BulkJobRepository
+	sessionService    session.Service
+	auditService      audit.Service
+	cacheService      cache.Service
+	approvalService   approval.Service
+	outboxService     outbox.Service
+	cfg               *config.Configuration
 }
 
-func NewUserService(userRepository Repository, cfg *config.Configuration) Service {
+func NewUserService(userRepository Repository,
+	bulkJobRepository BulkJobRepository,
+	sessionService session.Service,
+	auditService audit.Service,
+	cacheService cache.Service,
+	approvalService approval.Service,
+	outboxService outbox.Service,
+	cfg *config.Configuration) Service {
 	return &userService{
-		userRepository: userRepository,
-		cfg:            cfg,
+		userRepository:    userRepository,
+		bulkJobRepository: bulkJobRepository,
+		sessionService:    sessionService,
+		auditService:      auditService,
+		cacheService:      cacheService,
+		approvalService:   approvalService,
+		outboxService:     outboxService,
+		cfg:               cfg,
 	}
 }
 
@@ -83,39 +123,250 @@ func (s *userService) GetUserStats(ctx context.Context) (*models.Stats, error) {
 	return stats, nil
 }
 
+// GetUserStatsTimeSeries returns registrations, activations and suspensions per bucket.
+// The query must be normalized with NormalizeTimeSeriesQuery.
+func (s *userService) GetUserStatsTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	registrations, err := s.userRepository.GetRegistrationSeries(ctx, query)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get registration series from repository")
+		return nil, err
+	}
+
+	statusChanges, err := s.auditService.GetStatusChangeSeries(ctx, query, []string{StatusActive, StatusSuspended})
+	if err != nil {
+		return nil, err
+	}
+
+	series := buildTimeSeries(query, registrations, statusChanges)
+
+	logrus.WithFields(logrus.Fields{
+		"from": query.From, "to": query.To, "interval": query.Interval, "buckets": len(series.Buckets),
+	}).Info("Successfully retrieved user statistics time series")
+
+	return series, nil
+}
+
+// GetUserByID returns the full admin view of a user, including soft deleted ones
+func (s *userService) GetUserByID(ctx context.Context, id string) (*Details, error) {
+	userID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInvalidParams
+	}
+
+	user, err := s.userRepository.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to get user from repository")
+		return nil, err
+	}
+
+	activeSessions, err := s.sessionService.CountActiveSessions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": id, "active_sessions": activeSessions,
+	}).Debug("Successfully retrieved user details")
+
+	return user.ToDetails(activeSessions), nil
+}
+
 // ActivateUser activates a user
-func (s *userService) ActivateUser(ctx context.Context, id string) error {
-	return s.updateUserStatus(ctx, id, StatusActive)
+func (s *userService) ActivateUser(ctx context.Context, id string, actor *models.Actor) error {
+	return s.updateUserStatus(ctx, id, StatusActive, audit.ActionActivateUser, nil, actor)
 }
 
 // DeactivateUser deactivates a user
-func (s *userService) DeactivateUser(ctx context.Context, id string) error {
-	return s.updateUserStatus(ctx, id, StatusInactive)
+func (s *userService) DeactivateUser(ctx context.Context, id string, actor *models.Actor) error {
+	return s.updateUserStatus(ctx, id, StatusInactive, audit.ActionDeactivateUser, nil, actor)
 }
 
-// SuspendUser suspends a user
-func (s *userService) SuspendUser(ctx context.Context, id string) error {
-	return s.updateUserStatus(ctx, id, StatusSuspended)
+// SuspendUser suspends a user with a mandatory reason and an optional end date
+func (s *userService) SuspendUser(ctx context.Context, id string, req *SuspendUserRequest, actor *models.Actor) error {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return models.ErrSuspensionReasonRequired
+	}
+
+	now := time.Now()
+	if req.Until != nil && !req.Until.After(now) {
+		return models.ErrInvalidSuspensionEnd
+	}
+
+	suspension := &Suspension{
+		Reason:      reason,
+		Note:        strings.TrimSpace(req.Note),
+		Until:       req.Until,
+		SuspendedAt: now,
+		SuspendedBy: actor.ID,
+	}
+
+	return s.updateUserStatus(ctx, id, StatusSuspended, audit.ActionSuspendUser, suspension, actor)
+}
+
+// ReactivateExpiredSuspensions activates users whose suspension end date has passed
+func (s *userService) ReactivateExpiredSuspensions(ctx context.Context) (int, error) {
+	users, err := s.userRepository.FindExpiredSuspensions(ctx, time.Now(), s.cfg.Jobs.SuspensionExpiry.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	actor := &models.Actor{ID: models.SystemActorID, Email: s.cfg.App.Name}
+
+	reactivated := 0
+	for _, user := range users {
+		err := s.updateUserStatus(ctx, user.ID.Hex(), StatusActive, audit.ActionSuspensionExpired, nil, actor)
+		if err != nil {
+			logrus.WithError(err).WithField("user_id", user.ID.Hex()).Error("Failed to reactivate user after suspension expiry")
+			continue
+		}
+		reactivated++
+	}
+
+	return reactivated, nil
 }
 
 // updateUserStatus is a helper method to update user status
-func (s *userService) updateUserStatus(ctx context.Context, id, status string) error {
+func (s *userService) updateUserStatus(ctx context.Context, id, status, action string, suspension *Suspension, actor *models.Actor) error {
+	current, err := s.getForStatusUpdate(ctx, id, status, actor)
+	if err != nil {
+		return err
+	}
+
+	if s.approvalService.RequiresApproval(action, current.Role, actor) {
+		return s.requestApproval(ctx, current, action, suspensionMetadata(suspension), actor)
+	}
+
+	return s.applyStatusChange(ctx, current, status, action, suspension, actor, nil)
+}
+
+// getForStatusUpdate loads the target user and validates the requested transition
+func (s *userService) getForStatusUpdate(ctx context.Context, id, status string, actor *models.Actor) (*User, error) {
 	userID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return models.ErrInvalidParams
+		return nil, models.ErrInvalidParams
+	}
+
+	current, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to get user for status update")
+		return nil, err
 	}
 
-	err = s.userRepository.UpdateStatus(ctx, userID, status)
+	if err := checkTransition(actor, current, status); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"user_id": id, "from": current.Status, "to": status, "actor_id": actor.ID,
+		}).Warn("User status transition rejected")
+		return nil, err
+	}
 
+	return current, nil
+}
+
+// applyStatusChange persists a validated status change and runs its side effects
+func (s *userService) applyStatusChange(ctx context.Context, current *User, status, action string,
+	suspension *Suspension, actor *models.Actor, extraMetadata map[string]string) error {
+	id := current.ID.Hex()
+
+	// Conditional on the status we validated against, so concurrent changes are detected.
+	// The event is written in the same transaction and relayed to the broker afterwards.
+	var previous *User
+	err := s.outboxService.RunInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		previous, err = s.userRepository.UpdateStatus(txCtx, current.ID, current.Status, status, suspension)
+		if err != nil {
+			return err
+		}
+
+		eventType, payload := statusEvent(id, previous.Status, status, suspension)
+		return s.enqueueEvent(txCtx, id, eventType, payload, actor)
+	})
 	if err != nil {
 		logrus.Errorf("Error updating user status for %s to %s: %v", id, status, err)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrStatusConflict
+		}
 		return err
 	}
 
+	metadata := suspensionMetadata(suspension)
+	if metadata == nil && len(extraMetadata) > 0 {
+		metadata = make(map[string]string, len(extraMetadata))
+	}
+	for key, value := range extraMetadata {
+		metadata[key] = value
+	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Actor:        *actor,
+		Action:       action,
+		TargetUserID: id,
+		TargetEmail:  previous.Email,
+		TargetRole:   previous.Role,
+		BeforeStatus: previous.Status,
+		AfterStatus:  status,
+		Metadata:     metadata,
+	})
+
+	s.invalidateStats(ctx)
+
+	if status != StatusActive {
+		s.revokeSessions(ctx, id, actor)
+	}
+
 	logrus.Infof("User %s status updated to %s", id, status)
 	return nil
 }
 
+// invalidateStats drops cached statistics after a user mutation; a failure only
+// leaves the dashboard stale until the cache expires, so it is logged
+func (s *userService) invalidateStats(ctx context.Context) {
+	if err := s.cacheService.InvalidateUserStats(ctx); err != nil {
+		logrus.WithError(err).Warn("Failed to invalidate user stats cache")
+	}
+}
+
+// revokeSessions logs the user out after losing active status; the status change
+// has already been applied, so a failure here is logged rather than returned
+func (s *userService) revokeSessions(ctx context.Context, id string, actor *models.Actor) {
+	if _, err := s.sessionService.RevokeAllForUser(ctx, id, session.ReasonUserStatusChanged, actor); err != nil {
+		logrus.WithError(err).WithField("user_id", id).Error("Failed to revoke user sessions")
+	}
+}
+
+// recordAudit writes an audit entry; the mutation has already been applied,
+// so a failure here is logged rather than returned to the caller
+func (s *userService) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":         entry.Action,
+			"target_user_id": entry.TargetUserID,
+		}).Error("Failed to record audit entry")
+	}
+}
+
+// suspensionMetadata flattens suspension details for the audit trail
+func suspensionMetadata(suspension *Suspension) map[string]string {
+	if suspension == nil {
+		return nil
+	}
+
+	metadata := map[string]string{"reason": suspension.Reason}
+	if suspension.Note != "" {
+		metadata["note"] = suspension.Note
+	}
+	if suspension.Until != nil {
+		metadata["until"] = suspension.Until.UTC().Format(time.RFC3339)
+	}
+	return metadata
+}
+
 // validateRequest validates and normalizes the GetAllUsersRequest
 func (s *userService) validateRequest(req *GetAllUsersRequest) error {
 	if req.Limit <= 0 {
@@ -141,6 +392,9 @@ func (s *userService) validateRequest(req *GetAllUsersRequest) error {
 	if req.SortOrder != "" && !isValidSortOrder(req.SortOrder) {
 		req.SortOrder = ""
 	}
+	if req.IncludeDeleted != IncludeDeletedTrue && req.IncludeDeleted != IncludeDeletedOnly {
+		req.IncludeDeleted = ""
+	}
 
 	// Set default sort by registration date if not specified or invalid
 	if req.SortBy == "" {
@@ -156,7 +410,7 @@ func (s *userService) validateRequest(req *GetAllUsersRequest) error {
 
 // isValidRole validates if role is valid
 func isValidRole(role string) bool {
-	validRoles := []string{RoleAdmin, RoleClient, RoleExecutor}
+	validRoles := []string{RoleAdmin, RoleClient, RoleExecutor, RoleSupport, RoleModerator, RoleSuperAdmin}
 	for _, validRole := range validRoles {
 		if validRole == role {
 			return true
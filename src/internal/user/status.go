@@ -0,0 +1,42 @@
+package user
+
+import "handyhub-admin-svc/src/internal/models"
+
+// statusTransitions lists the statuses a user may be moved to from each status
+var statusTransitions = map[string][]string{
+	StatusActive:    {StatusInactive, StatusSuspended},
+	StatusInactive:  {StatusActive, StatusSuspended},
+	StatusSuspended: {StatusActive, StatusInactive},
+}
+
+// canTransition checks if the status machine allows moving from one status to another
+func canTransition(from, to string) bool {
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransition validates a status change of target requested by actor
+func checkTransition(actor *models.Actor, target *User, to string) error {
+	if !canTransition(target.Status, to) {
+		return models.ErrInvalidStatusTransition
+	}
+
+	// Activation never locks anyone out, other transitions are guarded
+	if to == StatusActive {
+		return nil
+	}
+
+	if actor.ID == target.ID.Hex() {
+		return models.ErrSelfStatusChange
+	}
+
+	if target.IsStaff() {
+		return models.ErrAdminStatusChange
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"errors"
+	"handyhub-admin-svc/src/internal/models"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCheckTransition(t *testing.T) {
+	admin := &models.Actor{ID: primitive.NewObjectID().Hex(), Role: RoleAdmin}
+	self := &User{ID: primitive.NewObjectID(), Role: RoleClient}
+
+	tests := []struct {
+		name   string
+		actor  *models.Actor
+		target *User
+		to     string
+		want   error
+	}{
+		{
+			name:   "active client can be deactivated",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleClient, Status: StatusActive},
+			to:     StatusInactive,
+		},
+		{
+			name:   "active executor can be suspended",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleExecutor, Status: StatusActive},
+			to:     StatusSuspended,
+		},
+		{
+			name:   "suspended client can be activated",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleClient, Status: StatusSuspended},
+			to:     StatusActive,
+		},
+		{
+			name:   "inactive client can be suspended",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleClient, Status: StatusInactive},
+			to:     StatusSuspended,
+		},
+		{
+			name:   "same status is not a transition",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleClient, Status: StatusActive},
+			to:     StatusActive,
+			want:   models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "unknown target status",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleClient, Status: StatusActive},
+			to:     "banned",
+			want:   models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "unknown current status",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleClient, Status: "pending"},
+			to:     StatusActive,
+			want:   models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "own account cannot be deactivated",
+			actor:  &models.Actor{ID: self.ID.Hex(), Role: RoleAdmin},
+			target: &User{ID: self.ID, Role: RoleClient, Status: StatusActive},
+			to:     StatusInactive,
+			want:   models.ErrSelfStatusChange,
+		},
+		{
+			name:   "own account can be activated",
+			actor:  &models.Actor{ID: self.ID.Hex(), Role: RoleAdmin},
+			target: &User{ID: self.ID, Role: RoleClient, Status: StatusInactive},
+			to:     StatusActive,
+		},
+		{
+			name:   "admin cannot be suspended",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleAdmin, Status: StatusActive},
+			to:     StatusSuspended,
+			want:   models.ErrAdminStatusChange,
+		},
+		{
+			name:   "admin cannot be deactivated",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleAdmin, Status: StatusActive},
+			to:     StatusInactive,
+			want:   models.ErrAdminStatusChange,
+		},
+		{
+			name:   "admin can be activated",
+			target: &User{ID: primitive.NewObjectID(), Role: RoleAdmin, Status: StatusInactive},
+			to:     StatusActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor := tt.actor
+			if actor == nil {
+				actor = admin
+			}
+
+			if err := checkTransition(actor, tt.target, tt.to); !errors.Is(err, tt.want) {
+				t.Errorf("checkTransition() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
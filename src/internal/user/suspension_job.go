@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"handyhub-admin-svc/src/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SuspensionExpiryJob periodically reactivates users whose suspension has expired
+type SuspensionExpiryJob struct {
+	service  Service
+	cfg      *config.JobConfig
+	timeout  time.Duration
+	stop     chan struct{}
+	finished chan struct{}
+}
+
+func NewSuspensionExpiryJob(service Service, cfg *config.Configuration) *SuspensionExpiryJob {
+	return &SuspensionExpiryJob{
+		service:  service,
+		cfg:      &cfg.Jobs.SuspensionExpiry,
+		timeout:  time.Duration(cfg.App.Timeout) * time.Second,
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+// Start runs the job in the background until Stop is called
+func (j *SuspensionExpiryJob) Start() {
+	if !j.cfg.Enabled {
+		logrus.Info("Suspension expiry job is disabled")
+		close(j.finished)
+		return
+	}
+
+	interval := time.Duration(j.cfg.IntervalSeconds) * time.Second
+	logrus.WithField("interval", interval).Info("Starting suspension expiry job")
+
+	go func() {
+		defer close(j.finished)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				j.run()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop signals the job to exit and waits for the current run to finish
+func (j *SuspensionExpiryJob) Stop() {
+	close(j.stop)
+	<-j.finished
+	logrus.Info("Suspension expiry job stopped")
+}
+
+func (j *SuspensionExpiryJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	reactivated, err := j.service.ReactivateExpiredSuspensions(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Suspension expiry job failed")
+		return
+	}
+
+	if reactivated > 0 {
+		logrus.WithField("reactivated", reactivated).Info("Reactivated users with expired suspensions")
+	}
+}
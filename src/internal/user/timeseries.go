@@ -0,0 +1,120 @@
+package user
+
+import (
+	"handyhub-admin-svc/src/internal/models"
+	"time"
+)
+
+const (
+	defaultTimeSeriesDays = 30
+	maxTimeSeriesBuckets  = 366
+)
+
+// NormalizeTimeSeriesQuery applies defaults and aligns the range to whole buckets,
+// so equal dashboard queries share the same cache entry. To is exclusive: a To inside
+// a bucket includes that bucket, a To on a bucket start does not. Normalizing an
+// already normalized query leaves it unchanged.
+func NormalizeTimeSeriesQuery(query *models.TimeSeriesQuery) error {
+	if query.Interval == "" {
+		query.Interval = models.IntervalDay
+	}
+	if !isValidInterval(query.Interval) {
+		return models.ErrInvalidInterval
+	}
+
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddDate(0, 0, -defaultTimeSeriesDays)
+	}
+	if query.From.After(query.To) {
+		return models.ErrInvalidTimeRange
+	}
+
+	query.From = truncateToInterval(query.From, query.Interval)
+	to := truncateToInterval(query.To, query.Interval)
+	if !to.Equal(query.To) || !to.After(query.From) {
+		to = addInterval(to, query.Interval)
+	}
+	query.To = to
+
+	if len(bucketStarts(query)) > maxTimeSeriesBuckets {
+		return models.ErrInvalidTimeRange
+	}
+
+	return nil
+}
+
+func isValidInterval(interval string) bool {
+	return interval == models.IntervalDay || interval == models.IntervalWeek || interval == models.IntervalMonth
+}
+
+// truncateToInterval mirrors Mongo $dateTrunc in UTC with weeks starting on Monday
+func truncateToInterval(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch interval {
+	case models.IntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		return day.AddDate(0, 0, -offset)
+	case models.IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func addInterval(t time.Time, interval string) time.Time {
+	switch interval {
+	case models.IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case models.IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func bucketStarts(query *models.TimeSeriesQuery) []time.Time {
+	var starts []time.Time
+	for start := query.From; start.Before(query.To); start = addInterval(start, query.Interval) {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// buildTimeSeries places aggregation rows into a gap-free list of buckets
+func buildTimeSeries(query *models.TimeSeriesQuery,
+	registrations, statusChanges []models.TimeSeriesResult) *models.TimeSeries {
+	starts := bucketStarts(query)
+	buckets := make([]*models.TimeSeriesBucket, len(starts))
+	index := make(map[int64]*models.TimeSeriesBucket, len(starts))
+
+	for i, start := range starts {
+		buckets[i] = &models.TimeSeriesBucket{Start: start}
+		index[start.Unix()] = buckets[i]
+	}
+
+	for _, row := range registrations {
+		if bucket, ok := index[row.Bucket.UTC().Unix()]; ok {
+			bucket.Registrations.Add(row.Role, row.Count)
+		}
+	}
+
+	for _, row := range statusChanges {
+		bucket, ok := index[row.Bucket.UTC().Unix()]
+		if !ok {
+			continue
+		}
+		switch row.Status {
+		case StatusActive:
+			bucket.Activations.Add(row.Role, row.Count)
+		case StatusSuspended:
+			bucket.Suspensions.Add(row.Role, row.Count)
+		}
+	}
+
+	return &models.TimeSeries{TimeSeriesQuery: *query, Buckets: buckets}
+}
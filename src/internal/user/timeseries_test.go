@@ -0,0 +1,237 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handyhub-admin-svc/src/internal/audit"
+	"handyhub-admin-svc/src/internal/cache"
+	"handyhub-admin-svc/src/internal/config"
+	"handyhub-admin-svc/src/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNormalizeTimeSeriesQuery(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		query    models.TimeSeriesQuery
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  error
+	}{
+		{
+			name:     "day buckets cover the whole last day",
+			query:    models.TimeSeriesQuery{From: date(2024, 3, 1, 15), To: date(2024, 3, 5, 9), Interval: models.IntervalDay},
+			wantFrom: date(2024, 3, 1, 0),
+			wantTo:   date(2024, 3, 6, 0),
+		},
+		{
+			name:     "interval defaults to day",
+			query:    models.TimeSeriesQuery{From: date(2024, 3, 1, 0), To: date(2024, 3, 2, 12)},
+			wantFrom: date(2024, 3, 1, 0),
+			wantTo:   date(2024, 3, 3, 0),
+		},
+		{
+			name:     "to on a bucket start is exclusive",
+			query:    models.TimeSeriesQuery{From: date(2024, 3, 1, 0), To: date(2024, 3, 3, 0), Interval: models.IntervalDay},
+			wantFrom: date(2024, 3, 1, 0),
+			wantTo:   date(2024, 3, 3, 0),
+		},
+		{
+			name:     "equal from and to cover one bucket",
+			query:    models.TimeSeriesQuery{From: date(2024, 3, 1, 0), To: date(2024, 3, 1, 0), Interval: models.IntervalDay},
+			wantFrom: date(2024, 3, 1, 0),
+			wantTo:   date(2024, 3, 2, 0),
+		},
+		{
+			name:     "week buckets start on Monday",
+			query:    models.TimeSeriesQuery{From: date(2024, 3, 6, 10), To: date(2024, 3, 17, 10), Interval: models.IntervalWeek},
+			wantFrom: date(2024, 3, 4, 0),
+			wantTo:   date(2024, 3, 18, 0),
+		},
+		{
+			name:     "month buckets start on the first",
+			query:    models.TimeSeriesQuery{From: date(2024, 1, 31, 23), To: date(2024, 2, 29, 12), Interval: models.IntervalMonth},
+			wantFrom: date(2024, 1, 1, 0),
+			wantTo:   date(2024, 3, 1, 0),
+		},
+		{
+			name: "times are converted to UTC",
+			query: models.TimeSeriesQuery{
+				From:     time.Date(2024, 3, 2, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+				To:       date(2024, 3, 2, 12),
+				Interval: models.IntervalDay,
+			},
+			wantFrom: date(2024, 3, 1, 0),
+			wantTo:   date(2024, 3, 3, 0),
+		},
+		{
+			name:    "unknown interval",
+			query:   models.TimeSeriesQuery{From: date(2024, 3, 1, 0), To: date(2024, 3, 2, 0), Interval: "hour"},
+			wantErr: models.ErrInvalidInterval,
+		},
+		{
+			name:    "from after to",
+			query:   models.TimeSeriesQuery{From: date(2024, 3, 2, 0), To: date(2024, 3, 1, 0), Interval: models.IntervalDay},
+			wantErr: models.ErrInvalidTimeRange,
+		},
+		{
+			name:    "too many buckets",
+			query:   models.TimeSeriesQuery{From: date(2020, 1, 1, 0), To: date(2024, 1, 1, 0), Interval: models.IntervalDay},
+			wantErr: models.ErrInvalidTimeRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			err := NormalizeTimeSeriesQuery(&query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeTimeSeriesQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !query.From.Equal(tt.wantFrom) || !query.To.Equal(tt.wantTo) {
+				t.Errorf("NormalizeTimeSeriesQuery() range = [%v, %v), want [%v, %v)", query.From, query.To, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestNormalizeTimeSeriesQueryDefaults(t *testing.T) {
+	var query models.TimeSeriesQuery
+	if err := NormalizeTimeSeriesQuery(&query); err != nil {
+		t.Fatalf("NormalizeTimeSeriesQuery() error = %v", err)
+	}
+
+	if query.Interval != models.IntervalDay {
+		t.Errorf("Interval = %q, want %q", query.Interval, models.IntervalDay)
+	}
+	if buckets := len(bucketStarts(&query)); buckets != defaultTimeSeriesDays+1 {
+		t.Errorf("buckets = %d, want %d", buckets, defaultTimeSeriesDays+1)
+	}
+}
+
+func TestNormalizeTimeSeriesQueryIdempotent(t *testing.T) {
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		query       models.TimeSeriesQuery
+		wantBuckets int
+	}{
+		{name: "days", query: models.TimeSeriesQuery{From: date(3, 1, 15), To: date(3, 5, 9), Interval: models.IntervalDay}, wantBuckets: 5},
+		{name: "aligned days", query: models.TimeSeriesQuery{From: date(3, 1, 0), To: date(3, 5, 0), Interval: models.IntervalDay}, wantBuckets: 4},
+		{name: "weeks", query: models.TimeSeriesQuery{From: date(3, 6, 10), To: date(3, 17, 10), Interval: models.IntervalWeek}, wantBuckets: 2},
+		{name: "months", query: models.TimeSeriesQuery{From: date(1, 31, 23), To: date(2, 29, 12), Interval: models.IntervalMonth}, wantBuckets: 2},
+		{name: "single day", query: models.TimeSeriesQuery{From: date(3, 1, 0), To: date(3, 1, 0), Interval: models.IntervalDay}, wantBuckets: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			once := tt.query
+			if err := NormalizeTimeSeriesQuery(&once); err != nil {
+				t.Fatalf("NormalizeTimeSeriesQuery() error = %v", err)
+			}
+			twice := once
+			if err := NormalizeTimeSeriesQuery(&twice); err != nil {
+				t.Fatalf("NormalizeTimeSeriesQuery() second call error = %v", err)
+			}
+
+			if twice != once {
+				t.Errorf("normalizing twice = %+v, want %+v", twice, once)
+			}
+			if buckets := len(buildTimeSeries(&twice, nil, nil).Buckets); buckets != tt.wantBuckets {
+				t.Errorf("buckets = %d, want %d", buckets, tt.wantBuckets)
+			}
+		})
+	}
+}
+
+// fakeSeriesRepository counts how often the registration series is read
+type fakeSeriesRepository struct {
+	Repository
+	reads int
+}
+
+func (r *fakeSeriesRepository) GetRegistrationSeries(context.Context, *models.TimeSeriesQuery) ([]models.TimeSeriesResult, error) {
+	r.reads++
+	return nil, nil
+}
+
+type fakeSeriesAuditService struct {
+	audit.Service
+}
+
+func (s *fakeSeriesAuditService) GetStatusChangeSeries(context.Context, *models.TimeSeriesQuery, []string) ([]models.TimeSeriesResult, error) {
+	return nil, nil
+}
+
+// fakeSeriesCache keys cached series the way the Redis cache does, by interval and range
+type fakeSeriesCache struct {
+	cache.Service
+	series map[string]*models.TimeSeries
+}
+
+func seriesKey(query *models.TimeSeriesQuery) string {
+	return fmt.Sprintf("%s:%d:%d", query.Interval, query.From.Unix(), query.To.Unix())
+}
+
+func (c *fakeSeriesCache) SaveUserStatsTimeSeries(_ context.Context, query *models.TimeSeriesQuery, series *models.TimeSeries) error {
+	c.series[seriesKey(query)] = series
+	return nil
+}
+
+func (c *fakeSeriesCache) GetUserStatsTimeSeries(_ context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	return c.series[seriesKey(query)], nil
+}
+
+func TestGetUserStatsTimeSeriesCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Configuration{}
+	cfg.App.Timeout = 5
+
+	repository := &fakeSeriesRepository{}
+	cacheService := &fakeSeriesCache{series: make(map[string]*models.TimeSeries)}
+	service := &userService{userRepository: repository, auditService: &fakeSeriesAuditService{}, cfg: cfg}
+	h := NewHandler(cfg, service, cacheService)
+
+	router := gin.New()
+	router.GET("/stats/timeseries", h.GetUserStatsTimeSeries)
+
+	for i, wantReads := range []int{1, 1} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stats/timeseries?from=2024-03-01&to=2024-03-05&interval=day", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d", i+1, recorder.Code, http.StatusOK)
+		}
+
+		var response struct {
+			Data models.TimeSeries `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("request %d: failed to decode response: %v", i+1, err)
+		}
+		// The dates are inclusive, so March 1st to 5th is five days
+		if buckets := len(response.Data.Buckets); buckets != 5 {
+			t.Errorf("request %d buckets = %d, want 5", i+1, buckets)
+		}
+		if repository.reads != wantReads {
+			t.Errorf("request %d repository reads = %d, want %d", i+1, repository.reads, wantReads)
+		}
+	}
+}